@@ -0,0 +1,35 @@
+package common
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	ActorMetadataKey  = "x-actor-service"
+	ReasonMetadataKey = "x-change-reason"
+)
+
+// WithActor tags outgoing gRPC calls with the calling service and the reason
+// for the change, so the receiving service can keep an audit trail.
+func WithActor(ctx context.Context, actor, reason string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, actor, ReasonMetadataKey, reason)
+}
+
+// ActorFromIncomingContext returns what the caller set with WithActor.
+func ActorFromIncomingContext(ctx context.Context) (actor, reason string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+
+	if v := md.Get(ActorMetadataKey); len(v) > 0 {
+		actor = v[0]
+	}
+	if v := md.Get(ReasonMetadataKey); len(v) > 0 {
+		reason = v[0]
+	}
+
+	return actor, reason
+}
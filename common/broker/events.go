@@ -1,6 +1,11 @@
 package broker
 
 const (
-	OrderCreatedEvent = "order.created"
-	OrderPaidEvent    = "order.paid"
+	OrderCreatedEvent   = "order.created"
+	OrderPaidEvent      = "order.paid"
+	OrderCancelledEvent = "order.cancelled"
+	OrderExpiredEvent   = "order.expired"
+	StockLowEvent       = "stock.low"
+	StockDepletedEvent  = "stock.depleted"
+	StockExpiringEvent  = "stock.expiring"
 )
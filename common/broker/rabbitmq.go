@@ -36,6 +36,31 @@ func Connect(user, pass, host, port string) (*amqp.Channel, func() error) {
 		log.Fatal(err)
 	}
 
+	err = ch.ExchangeDeclare(OrderCancelledEvent, "fanout", true, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ch.ExchangeDeclare(OrderExpiredEvent, "fanout", true, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ch.ExchangeDeclare(StockLowEvent, "fanout", true, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ch.ExchangeDeclare(StockDepletedEvent, "fanout", true, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ch.ExchangeDeclare(StockExpiringEvent, "fanout", true, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	err = createDLQAndDLX(ch)
 	if err != nil {
 		log.Fatal(err)
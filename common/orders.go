@@ -0,0 +1,45 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const MaxOrderUpdateAttempts = 5
+
+// UpdateOrderWithRetry reads the order, applies mutate and writes it back
+// with the version it read. When another writer updated the order in the
+// meantime the orders service answers Aborted and the cycle starts over with
+// a fresh copy, so concurrent updates are never lost. An error from mutate
+// leaves the order as it is and is returned as is.
+func UpdateOrderWithRetry(ctx context.Context, client pb.OrderServiceClient, orderID, customerID string, mutate func(*pb.Order) error) (*pb.Order, error) {
+	var err error
+
+	for attempt := 0; attempt < MaxOrderUpdateAttempts; attempt++ {
+		var o *pb.Order
+		o, err = client.GetOrder(ctx, &pb.GetOrderRequest{
+			OrderID:    orderID,
+			CustomerID: customerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mutate(o); err != nil {
+			return nil, err
+		}
+
+		o, err = client.UpdateOrder(ctx, o)
+		if status.Code(err) != codes.Aborted {
+			return o, err
+		}
+
+		time.Sleep(time.Duration(attempt+1) * 50 * time.Millisecond)
+	}
+
+	return nil, err
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"fmt"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLocation is the kitchen serving the orders that don't name a
+// location, and holding the stock of requests that don't name one.
+const DefaultLocation = "main"
+
+// LocationOrDefault returns the location, or DefaultLocation when it is empty.
+func LocationOrDefault(location string) string {
+	if location == "" {
+		return DefaultLocation
+	}
+
+	return location
+}
+
+// Availability statuses of a requested item.
+const (
+	AvailabilityOK           = "ok"
+	AvailabilityInsufficient = "insufficient"
+	AvailabilityUnknown      = "unknown"
+	// the item exists but its schedule doesn't allow ordering it now
+	AvailabilityUnavailableNow = "unavailable_now"
+	// the item is an ingredient that can't be ordered on its own
+	AvailabilityNotForSale = "not_for_sale"
+)
+
+// NewStockError is the FailedPrecondition error for an order that can't be
+// supplied. It carries a PreconditionFailure listing every item that is not
+// ok, with the availability status as the violation type and the item ID as
+// its subject.
+func NewStockError(availability []*pb.ItemAvailability) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, a := range availability {
+		if a.Status == AvailabilityOK {
+			continue
+		}
+
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        a.Status,
+			Subject:     a.ItemID,
+			Description: describeAvailability(a),
+		})
+	}
+
+	st := status.New(codes.FailedPrecondition, ErrNoStock.Error())
+
+	withDetails, err := st.WithDetails(failure)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+func describeAvailability(a *pb.ItemAvailability) string {
+	switch a.Status {
+	case AvailabilityUnknown:
+		return fmt.Sprintf("item %s does not exist", a.ItemID)
+	case AvailabilityInsufficient:
+		return fmt.Sprintf("only %d of the %d requested are available", a.Available, a.Requested)
+	case AvailabilityUnavailableNow:
+		return fmt.Sprintf("item %s can't be ordered at this time", a.ItemID)
+	case AvailabilityNotForSale:
+		return fmt.Sprintf("item %s is not sold on its own", a.ItemID)
+	default:
+		return a.Status
+	}
+}
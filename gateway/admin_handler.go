@@ -0,0 +1,406 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+)
+
+func (h *handler) registerAdminRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/admin/items", h.requireAdmin(h.handleListItems))
+	mux.HandleFunc("POST /api/admin/items", h.requireAdmin(h.handleCreateItem))
+	mux.HandleFunc("PUT /api/admin/items/{itemID}", h.requireAdmin(h.handleUpdateItem))
+	mux.HandleFunc("DELETE /api/admin/items/{itemID}", h.requireAdmin(h.handleDeleteItem))
+	mux.HandleFunc("POST /api/admin/items/{itemID}/adjustments", h.requireAdmin(h.handleAdjustQuantity))
+	mux.HandleFunc("GET /api/admin/items/{itemID}/ledger", h.requireAdmin(h.handleGetItemLedger))
+	mux.HandleFunc("GET /api/admin/replenishment/suggestions", h.requireAdmin(h.handleGetReorderSuggestions))
+	mux.HandleFunc("GET /api/admin/purchase-orders", h.requireAdmin(h.handleListPurchaseOrders))
+	mux.HandleFunc("POST /api/admin/purchase-orders", h.requireAdmin(h.handleCreatePurchaseOrder))
+	mux.HandleFunc("GET /api/admin/purchase-orders/{id}", h.requireAdmin(h.handleGetPurchaseOrder))
+	mux.HandleFunc("POST /api/admin/purchase-orders/{id}/receive", h.requireAdmin(h.handleReceivePurchaseOrder))
+	mux.HandleFunc("POST /api/admin/purchase-orders/{id}/cancel", h.requireAdmin(h.handleCancelPurchaseOrder))
+}
+
+// requireAdmin only lets requests carrying the admin bearer token through.
+// Without a configured token the admin API is disabled altogether.
+func (h *handler) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.adminToken == "" {
+			common.WriteError(w, http.StatusForbidden, "the admin API is disabled")
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			common.WriteError(w, http.StatusUnauthorized, "admin credentials are required")
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+			common.WriteError(w, http.StatusForbidden, "admin role required")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (h *handler) handleListItems(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := parsePageSize(r)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &pb.ListItemsRequest{
+		PageSize:   pageSize,
+		PageToken:  r.URL.Query().Get("page_token"),
+		LocationID: r.URL.Query().Get("location"),
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	res, err := h.stock.ListItems(ctx, req)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) handleGetItemLedger(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := parsePageSize(r)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	ledger, err := h.stock.GetItemLedger(ctx, &pb.GetItemLedgerRequest{
+		ItemID:     r.PathValue("itemID"),
+		PageSize:   pageSize,
+		PageToken:  r.URL.Query().Get("page_token"),
+		LocationID: r.URL.Query().Get("location"),
+	})
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, ledger)
+}
+
+func (h *handler) handleCreateItem(w http.ResponseWriter, r *http.Request) {
+	var item pb.StockItem
+	if err := common.ReadJSON(r, &item); err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	created, err := h.stock.CreateItem(ctx, &item)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *handler) handleUpdateItem(w http.ResponseWriter, r *http.Request) {
+	var item pb.StockItem
+	if err := common.ReadJSON(r, &item); err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	itemID := r.PathValue("itemID")
+	if item.ID != "" && item.ID != itemID {
+		common.WriteError(w, http.StatusBadRequest, "the item ID can't be changed")
+		return
+	}
+	item.ID = itemID
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	updated, err := h.stock.UpdateItem(ctx, &item)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (h *handler) handleDeleteItem(w http.ResponseWriter, r *http.Request) {
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	deleted, err := h.stock.DeleteItem(ctx, r.PathValue("itemID"))
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, deleted)
+}
+
+func (h *handler) handleAdjustQuantity(w http.ResponseWriter, r *http.Request) {
+	var req AdjustQuantityRequest
+	if err := common.ReadJSON(r, &req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validateAdjustment(req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	p := &pb.AdjustQuantityRequest{
+		ItemID:     r.PathValue("itemID"),
+		Delta:      req.Delta,
+		Reason:     req.Reason,
+		Restock:    req.Restock,
+		LocationID: req.LocationID,
+		LotID:      req.LotID,
+	}
+	if req.ExpiresAt != nil {
+		p.ExpiresAt = req.ExpiresAt.Unix()
+	}
+
+	item, err := h.stock.AdjustQuantity(ctx, p)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, item)
+}
+
+func (h *handler) handleGetReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	windowDays, err := parseDays(r, "window_days")
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	coverDays, err := parseDays(r, "cover_days")
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	suggestions, err := h.stock.GetReorderSuggestions(ctx, &pb.ReorderSuggestionsRequest{
+		LocationID: r.URL.Query().Get("location"),
+		WindowDays: windowDays,
+		CoverDays:  coverDays,
+	})
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, suggestions)
+}
+
+func (h *handler) handleListPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := parsePageSize(r)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &pb.ListPurchaseOrdersRequest{
+		Status:     r.URL.Query().Get("status"),
+		LocationID: r.URL.Query().Get("location"),
+		PageSize:   pageSize,
+		PageToken:  r.URL.Query().Get("page_token"),
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	res, err := h.stock.ListPurchaseOrders(ctx, req)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) handleCreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req pb.CreatePurchaseOrderRequest
+	if err := common.ReadJSON(r, &req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	po, err := h.stock.CreatePurchaseOrder(ctx, &req)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, po)
+}
+
+func (h *handler) handleGetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	po, err := h.stock.GetPurchaseOrder(ctx, r.PathValue("id"))
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, po)
+}
+
+func (h *handler) handleReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	// without a body, everything outstanding was delivered
+	var req ReceivePurchaseOrderRequest
+	if err := common.ReadJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	p := &pb.ReceivePurchaseOrderRequest{ID: r.PathValue("id")}
+	for _, l := range req.Lines {
+		if l.Quantity <= 0 {
+			common.WriteError(w, http.StatusBadRequest, "received quantities must be positive")
+			return
+		}
+
+		line := &pb.ReceivedLine{ItemID: l.ItemID, Quantity: l.Quantity, LotID: l.LotID}
+		if l.ExpiresAt != nil {
+			line.ExpiresAt = l.ExpiresAt.Unix()
+		}
+		p.Lines = append(p.Lines, line)
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	po, err := h.stock.ReceivePurchaseOrder(ctx, p)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, po)
+}
+
+func (h *handler) handleCancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	po, err := h.stock.CancelPurchaseOrder(ctx, r.PathValue("id"))
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, po)
+}
+
+// parsePageSize reads the optional page_size query parameter, 0 when unset.
+func parsePageSize(r *http.Request) (int32, error) {
+	v := r.URL.Query().Get("page_size")
+	if v == "" {
+		return 0, nil
+	}
+
+	size, err := strconv.Atoi(v)
+	if err != nil || size <= 0 {
+		return 0, errors.New("page_size must be a positive number")
+	}
+
+	return int32(size), nil
+}
+
+// parseDays reads an optional number of days from the query, 0 when unset.
+func parseDays(r *http.Request, name string) (int32, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(v)
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number", name)
+	}
+
+	return int32(days), nil
+}
+
+func validateAdjustment(req AdjustQuantityRequest) error {
+	if req.Delta == 0 {
+		return errors.New("delta must not be zero")
+	}
+
+	if req.Restock && req.Delta < 0 {
+		return errors.New("a restock can't remove stock")
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		return errors.New("a reason is required")
+	}
+
+	if !req.Restock && (req.LotID != "" || req.ExpiresAt != nil) {
+		return errors.New("only restocks go into a lot")
+	}
+
+	return nil
+}
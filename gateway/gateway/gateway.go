@@ -9,4 +9,26 @@ import (
 type OrderGateway interface {
 	CreateOrder(context.Context, *pb.CreateOrderRequest) (*pb.Order, error)
 	GetOrder(ctx context.Context, orderID, customerID string) (*pb.Order, error)
+	ListOrders(context.Context, *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error)
+	CancelOrder(ctx context.Context, orderID, customerID, reason string) (*pb.Order, error)
+	GetOrderHistory(ctx context.Context, orderID, customerID string) (*pb.OrderHistory, error)
+	// WatchOrder calls onUpdate for every new version of the order until the
+	// order reaches a final status, ctx is done or onUpdate returns an error.
+	WatchOrder(ctx context.Context, orderID, customerID string, sinceVersion int64, onUpdate func(*pb.Order) error) error
+}
+
+// StockGateway manages the stock catalog on behalf of admins.
+type StockGateway interface {
+	ListItems(context.Context, *pb.ListItemsRequest) (*pb.ListItemsResponse, error)
+	CreateItem(context.Context, *pb.StockItem) (*pb.StockItem, error)
+	UpdateItem(context.Context, *pb.StockItem) (*pb.StockItem, error)
+	DeleteItem(ctx context.Context, itemID string) (*pb.StockItem, error)
+	AdjustQuantity(context.Context, *pb.AdjustQuantityRequest) (*pb.StockItem, error)
+	GetItemLedger(context.Context, *pb.GetItemLedgerRequest) (*pb.ItemLedger, error)
+	GetReorderSuggestions(context.Context, *pb.ReorderSuggestionsRequest) (*pb.ReorderSuggestions, error)
+	CreatePurchaseOrder(context.Context, *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id string) (*pb.PurchaseOrder, error)
+	ListPurchaseOrders(context.Context, *pb.ListPurchaseOrdersRequest) (*pb.ListPurchaseOrdersResponse, error)
+	ReceivePurchaseOrder(context.Context, *pb.ReceivePurchaseOrderRequest) (*pb.PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, id string) (*pb.PurchaseOrder, error)
 }
@@ -2,8 +2,11 @@ package gateway
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/discovery"
 )
@@ -24,6 +27,8 @@ func (g *gateway) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest) (*p
 
 	c := pb.NewOrderServiceClient(conn)
 
+	ctx = common.WithActor(ctx, "gateway", "order placed by customer")
+
 	return c.CreateOrder(ctx, p)
 }
 
@@ -40,3 +45,237 @@ func (g *gateway) GetOrder(ctx context.Context, orderID, customerID string) (*pb
 		CustomerID: customerID,
 	})
 }
+
+func (g *gateway) ListOrders(ctx context.Context, p *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewOrderServiceClient(conn)
+
+	return c.ListOrders(ctx, p)
+}
+
+func (g *gateway) CancelOrder(ctx context.Context, orderID, customerID, reason string) (*pb.Order, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewOrderServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "cancelled by customer")
+
+	return c.CancelOrder(ctx, &pb.CancelOrderRequest{
+		OrderID:    orderID,
+		CustomerID: customerID,
+		Reason:     reason,
+	})
+}
+
+func (g *gateway) GetOrderHistory(ctx context.Context, orderID, customerID string) (*pb.OrderHistory, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewOrderServiceClient(conn)
+
+	return c.GetOrderHistory(ctx, &pb.GetOrderRequest{
+		OrderID:    orderID,
+		CustomerID: customerID,
+	})
+}
+
+func (g *gateway) WatchOrder(ctx context.Context, orderID, customerID string, sinceVersion int64, onUpdate func(*pb.Order) error) error {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewOrderServiceClient(conn)
+
+	stream, err := c.WatchOrder(ctx, &pb.WatchOrderRequest{
+		OrderID:      orderID,
+		CustomerID:   customerID,
+		SinceVersion: sinceVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		o, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := onUpdate(o); err != nil {
+			return err
+		}
+	}
+}
+
+func (g *gateway) ListItems(ctx context.Context, p *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.ListItems(ctx, p)
+}
+
+func (g *gateway) CreateItem(ctx context.Context, p *pb.StockItem) (*pb.StockItem, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "item created by admin")
+
+	return c.CreateItem(ctx, p)
+}
+
+func (g *gateway) UpdateItem(ctx context.Context, p *pb.StockItem) (*pb.StockItem, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.UpdateItem(ctx, p)
+}
+
+func (g *gateway) DeleteItem(ctx context.Context, itemID string) (*pb.StockItem, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "item deleted by admin")
+
+	return c.DeleteItem(ctx, &pb.ItemRequest{ItemID: itemID})
+}
+
+func (g *gateway) AdjustQuantity(ctx context.Context, p *pb.AdjustQuantityRequest) (*pb.StockItem, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", p.Reason)
+
+	return c.AdjustQuantity(ctx, p)
+}
+
+func (g *gateway) GetItemLedger(ctx context.Context, p *pb.GetItemLedgerRequest) (*pb.ItemLedger, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.GetItemLedger(ctx, p)
+}
+
+func (g *gateway) GetReorderSuggestions(ctx context.Context, p *pb.ReorderSuggestionsRequest) (*pb.ReorderSuggestions, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.GetReorderSuggestions(ctx, p)
+}
+
+func (g *gateway) CreatePurchaseOrder(ctx context.Context, p *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "purchase order placed by admin")
+
+	return c.CreatePurchaseOrder(ctx, p)
+}
+
+func (g *gateway) GetPurchaseOrder(ctx context.Context, id string) (*pb.PurchaseOrder, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.GetPurchaseOrder(ctx, &pb.PurchaseOrderRequest{ID: id})
+}
+
+func (g *gateway) ListPurchaseOrders(ctx context.Context, p *pb.ListPurchaseOrdersRequest) (*pb.ListPurchaseOrdersResponse, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	return c.ListPurchaseOrders(ctx, p)
+}
+
+func (g *gateway) ReceivePurchaseOrder(ctx context.Context, p *pb.ReceivePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "purchase order received by admin")
+
+	return c.ReceivePurchaseOrder(ctx, p)
+}
+
+func (g *gateway) CancelPurchaseOrder(ctx context.Context, id string) (*pb.PurchaseOrder, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "gateway", "purchase order cancelled by admin")
+
+	return c.CancelPurchaseOrder(ctx, &pb.PurchaseOrderRequest{ID: id})
+}
@@ -1,32 +1,57 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/omsv2-gateway/gateway"
 	"go.opentelemetry.io/otel"
 	otelCodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	maxIdempotencyKeyLength = 255
+	// sseHeartbeatInterval keeps idle event streams from being cut by proxies
+	sseHeartbeatInterval = 15 * time.Second
+	// sseRetry is how long browsers wait before reconnecting, in milliseconds
+	sseRetry = 3000
+)
+
 type handler struct {
 	gateway gateway.OrderGateway
+	stock   gateway.StockGateway
+	// adminToken is the bearer token of the admin role
+	adminToken string
 }
 
-func NewHandler(gateway gateway.OrderGateway) *handler {
-	return &handler{gateway}
+func NewHandler(gateway gateway.OrderGateway, stock gateway.StockGateway, adminToken string) *handler {
+	return &handler{gateway, stock, adminToken}
 }
 
 func (h *handler) registerRoutes(mux *http.ServeMux) {
 	mux.Handle("/", http.FileServer(http.Dir("public")))
 
 	mux.HandleFunc("POST /api/customers/{customerID}/orders", h.HandleCreateOrder)
+	mux.HandleFunc("GET /api/customers/{customerID}/orders", h.handleListOrders)
 	mux.HandleFunc("GET /api/customers/{customerID}/orders/{orderID}", h.handleGetOrder)
+	mux.HandleFunc("POST /api/customers/{customerID}/orders/{orderID}/cancel", h.handleCancelOrder)
+	mux.HandleFunc("GET /api/customers/{customerID}/orders/{orderID}/history", h.handleGetOrderHistory)
+	mux.HandleFunc("GET /api/customers/{customerID}/orders/{orderID}/events", h.handleOrderEvents)
+
+	mux.HandleFunc("GET /api/menu", h.handleGetMenu)
+
+	h.registerAdminRoutes(mux)
 }
 
 func (h *handler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
@@ -43,18 +68,21 @@ func (h *handler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		common.WriteError(w, http.StatusBadRequest, "Idempotency-Key is too long")
+		return
+	}
+
 	o, err := h.gateway.CreateOrder(r.Context(), &pb.CreateOrderRequest{
-		CustomerID: customerId,
-		Items:      items,
+		CustomerID:     customerId,
+		Items:          items,
+		IdempotencyKey: idempotencyKey,
+		// the default location prepares the order when none is given
+		LocationID: r.URL.Query().Get("location"),
 	})
-	rStatus := status.Convert(err)
-	if rStatus != nil {
-		if rStatus.Code() != codes.InvalidArgument {
-			common.WriteError(w, http.StatusBadRequest, rStatus.Message())
-			return
-		}
-
-		common.WriteError(w, http.StatusInternalServerError, err.Error())
+	if err != nil {
+		writeRPCError(w, err)
 		return
 	}
 
@@ -109,3 +137,250 @@ func (h *handler) handleGetOrder(w http.ResponseWriter, r *http.Request) {
 
 	common.WriteJSON(w, http.StatusOK, o)
 }
+
+func (h *handler) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("customerID")
+	orderID := r.PathValue("orderID")
+
+	// the body is optional, an empty one cancels without a reason
+	var req CancelOrderRequest
+	if r.ContentLength != 0 {
+		if err := common.ReadJSON(r, &req); err != nil {
+			common.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	o, err := h.gateway.CancelOrder(ctx, orderID, customerID, req.Reason)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, o)
+}
+
+func (h *handler) handleGetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("customerID")
+	orderID := r.PathValue("orderID")
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	history, err := h.gateway.GetOrderHistory(ctx, orderID, customerID)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, history)
+}
+
+// handleOrderEvents streams order updates as Server-Sent Events. Every event
+// carries the order version as its ID, so a reconnecting browser sends it
+// back in Last-Event-ID and only receives what it missed.
+func (h *handler) handleOrderEvents(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("customerID")
+	orderID := r.PathValue("orderID")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		common.WriteError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	var sinceVersion int64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		version, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			common.WriteError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		sinceVersion = version
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	updates := make(chan *pb.Order)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- h.gateway.WatchOrder(ctx, orderID, customerID, sinceVersion, func(o *pb.Order) error {
+			select {
+			case updates <- o:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case o := <-updates:
+			data, err := json.Marshal(o)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: order\ndata: %s\n\n", o.Version, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case err := <-done:
+			if err != nil && ctx.Err() == nil {
+				data, _ := json.Marshal(map[string]string{"error": status.Convert(err).Message()})
+				fmt.Fprintf(w, "event: failure\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+			return
+		}
+	}
+}
+
+func (h *handler) handleListOrders(w http.ResponseWriter, r *http.Request) {
+	req, err := parseListOrdersRequest(r)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	res, err := h.gateway.ListOrders(ctx, req)
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, res)
+}
+
+// parseListOrdersRequest reads the listing filters from the query string:
+// status (repeatable or comma separated), created_after and created_before
+// (RFC 3339), page_size and page_token.
+func parseListOrdersRequest(r *http.Request) (*pb.ListOrdersRequest, error) {
+	q := r.URL.Query()
+
+	req := &pb.ListOrdersRequest{
+		CustomerID: r.PathValue("customerID"),
+		PageToken:  q.Get("page_token"),
+	}
+
+	for _, v := range q["status"] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				req.Statuses = append(req.Statuses, s)
+			}
+		}
+	}
+
+	if v := q.Get("created_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.New("created_after must be an RFC 3339 timestamp")
+		}
+		req.CreatedAfter = t.Unix()
+	}
+
+	if v := q.Get("created_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.New("created_before must be an RFC 3339 timestamp")
+		}
+		req.CreatedBefore = t.Unix()
+	}
+
+	if v := q.Get("page_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size <= 0 {
+			return nil, errors.New("page_size must be a positive number")
+		}
+		req.PageSize = int32(size)
+	}
+
+	return req, nil
+}
+
+// writeRPCError translates a gRPC status error into the matching HTTP
+// response. Errors about items that can't be supplied list them, so the
+// customer can be told exactly what is missing.
+func writeRPCError(w http.ResponseWriter, err error) {
+	rStatus := status.Convert(err)
+	code := httpStatusFromCode(rStatus.Code())
+
+	if items := unavailableItems(rStatus); len(items) > 0 {
+		common.WriteJSON(w, code, StockErrorResponse{
+			Error: rStatus.Message(),
+			Items: items,
+		})
+		return
+	}
+
+	common.WriteError(w, code, rStatus.Message())
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// unavailableItems reads the items listed by common.NewStockError.
+func unavailableItems(st *status.Status) []UnavailableItem {
+	var items []UnavailableItem
+
+	for _, d := range st.Details() {
+		failure, ok := d.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+
+		for _, v := range failure.Violations {
+			items = append(items, UnavailableItem{
+				ItemID:      v.Subject,
+				Status:      v.Type,
+				Description: v.Description,
+			})
+		}
+	}
+
+	return items
+}
@@ -17,6 +17,8 @@ var (
 	serviceName = "gateway"
 	httpAddr    = common.EnvString("HTTP_ADDR", ":8080")
 	consulAddr  = common.EnvString("CONSUL_ADDR", "localhost:8500")
+	// the admin API is disabled unless a token is set
+	adminToken = common.EnvString("ADMIN_TOKEN", "")
 )
 
 func main() {
@@ -44,9 +46,9 @@ func main() {
 
 	mux := http.NewServeMux()
 
-	ordersGateway := gateway.NewGRPCGateway(registry)
+	grpcGateway := gateway.NewGRPCGateway(registry)
 
-	handler := NewHandler(ordersGateway)
+	handler := NewHandler(grpcGateway, grpcGateway, adminToken)
 	handler.registerRoutes(mux)
 
 	log.Printf("Starting HTTP server at %s", httpAddr)
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.opentelemetry.io/otel"
+	otelCodes "go.opentelemetry.io/otel/codes"
+)
+
+// handleGetMenu lists the items customers can order from a location right
+// now, the default location unless the location query parameter names
+// another. Items that ran out of stock there are left out until they are
+// restocked, and scheduled items outside of their availability windows.
+func (h *handler) handleGetMenu(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := parsePageSize(r)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tr := otel.Tracer("http")
+	ctx, span := tr.Start(r.Context(), fmt.Sprintf("%s %s", r.Method, r.RequestURI))
+	defer span.End()
+
+	res, err := h.stock.ListItems(ctx, &pb.ListItemsRequest{
+		PageSize:        pageSize,
+		PageToken:       r.URL.Query().Get("page_token"),
+		ExcludeDepleted: true,
+		AvailableNow:    true,
+		SellableOnly:    true,
+		LocationID:      r.URL.Query().Get("location"),
+	})
+	if err != nil {
+		span.SetStatus(otelCodes.Error, err.Error())
+		writeRPCError(w, err)
+		return
+	}
+
+	menu := MenuResponse{
+		Items:         make([]MenuItem, 0, len(res.Items)),
+		NextPageToken: res.NextPageToken,
+	}
+	for _, item := range res.Items {
+		menu.Items = append(menu.Items, MenuItem{
+			ID:         item.ID,
+			Name:       item.Name,
+			UnitAmount: item.UnitAmount,
+			Currency:   item.Currency,
+		})
+	}
+
+	common.WriteJSON(w, http.StatusOK, menu)
+}
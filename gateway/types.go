@@ -1,8 +1,71 @@
 package main
 
-import pb "github.com/rikughi/commons/api"
+import (
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+)
 
 type CreateOrderRequest struct {
-	Order         *pb.Order `"json": order`
-	RedirectToURL string    `"json": redirectToURL`
+	Order         *pb.Order `json:"order"`
+	RedirectToURL string    `json:"redirectToURL"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+type AdjustQuantityRequest struct {
+	// Delta is added to the quantity on hand, negative to remove stock
+	Delta  int32  `json:"delta"`
+	Reason string `json:"reason"`
+	// Restock marks deliveries, which can only add stock
+	Restock bool `json:"restock"`
+	// LocationID is where the stock is, the default location when empty
+	LocationID string `json:"locationID"`
+	// LotID is the lot a restock goes into, a new one when empty
+	LotID string `json:"lotID,omitempty"`
+	// ExpiresAt is when the new lot of a restock expires, never when unset
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ReceivePurchaseOrderRequest lists what a delivery brought, everything the
+// purchase order is still waiting for when empty.
+type ReceivePurchaseOrderRequest struct {
+	Lines []ReceivedLine `json:"lines"`
+}
+
+type ReceivedLine struct {
+	ItemID   string `json:"itemID"`
+	Quantity int32  `json:"quantity"`
+	// LotID and ExpiresAt describe the lot the items go into, like for restocks
+	LotID     string     `json:"lotID,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// StockErrorResponse is returned when some items of an order can't be supplied.
+type StockErrorResponse struct {
+	Error string            `json:"error"`
+	Items []UnavailableItem `json:"items"`
+}
+
+type UnavailableItem struct {
+	ItemID string `json:"itemID"`
+	// Status is unknown for items that don't exist, unavailable_now for
+	// items that can't be ordered at this time, insufficient otherwise
+	Status      string `json:"status"`
+	Description string `json:"description"`
+}
+
+// MenuItem is the customer facing view of a stock item, stock levels stay private.
+type MenuItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	UnitAmount int64  `json:"unitAmount"`
+	Currency   string `json:"currency"`
+}
+
+type MenuResponse struct {
+	Items         []MenuItem `json:"items"`
+	NextPageToken string     `json:"nextPageToken,omitempty"`
 }
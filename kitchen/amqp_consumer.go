@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -12,17 +13,39 @@ import (
 	"github.com/rikughi/commons/broker"
 	"github.com/rikughi/omsv2-kitchen/gateway"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// cancelledRetention is how long a cancelled order is remembered so that its
+// queued order.paid message can be dropped.
+const cancelledRetention = time.Hour
+
+// cookedHeader marks the retries of orders that were cooked but couldn't be
+// marked ready.
+const cookedHeader = "x-cooked"
+
 type Consumer struct {
 	gateway gateway.KitchenGateway
+	// location is the kitchen this consumer cooks for, orders of other
+	// locations are left to their own kitchen
+	location string
+
+	mu        sync.Mutex
+	cancelled map[string]time.Time
 }
 
-func NewConsumer(gateway gateway.KitchenGateway) *Consumer {
-	return &Consumer{gateway}
+func NewConsumer(gateway gateway.KitchenGateway, location string) *Consumer {
+	return &Consumer{
+		gateway:   gateway,
+		location:  location,
+		cancelled: make(map[string]time.Time),
+	}
 }
 
 func (c *Consumer) Listen(ch *amqp.Channel) {
+	go c.listenOrderCancelled(ch)
+
 	q, err := ch.QueueDeclare(
 		"",    // name
 		true,  // durable
@@ -66,11 +89,50 @@ func (c *Consumer) Listen(ch *amqp.Channel) {
 				continue
 			}
 
-			if o.Status == "paid" {
+			// every kitchen gets every paid order
+			if o.LocationID != c.location {
+				messageSpan.End()
+				d.Ack(false)
+				continue
+			}
+
+			// retries of an order that was already cooked only have to mark
+			// it ready
+			cooked, _ := d.Headers[cookedHeader].(bool)
+
+			if o.Status == "paid" && !cooked {
+				if c.isCancelled(o.ID) {
+					log.Printf("dropping cancelled order %s", o.ID)
+					messageSpan.End()
+					d.Ack(false)
+					continue
+				}
+
+				// claim the order before cooking it, a redelivered message
+				// finds it already preparing or ready and is skipped
+				err := c.gateway.ClaimOrder(context.Background(), o.ID, o.CustomerID)
+				if status.Code(err) == codes.FailedPrecondition {
+					log.Printf("skipping order %s: %v", o.ID, err)
+					messageSpan.End()
+					d.Ack(false)
+					continue
+				}
+				if err != nil {
+					log.Printf("error updating the order %v", o)
+
+					if err := broker.HandleRetry(ch, &d); err != nil {
+						log.Printf("error handling the retry: %v", err.Error())
+					}
+					continue
+				}
+
 				cookOrder() // let him cook
 
 				messageSpan.AddEvent(fmt.Sprintf("Order Cooked: %v", o))
+				cooked = true
+			}
 
+			if cooked {
 				if err := c.gateway.UpdateOrder(context.Background(), &pb.Order{
 					Status:     "ready",
 					ID:         o.ID,
@@ -78,6 +140,11 @@ func (c *Consumer) Listen(ch *amqp.Channel) {
 				}); err != nil {
 					log.Printf("error updating the order %v", o)
 
+					if d.Headers == nil {
+						d.Headers = amqp.Table{}
+					}
+					d.Headers[cookedHeader] = true
+
 					if err := broker.HandleRetry(ch, &d); err != nil {
 						log.Printf("error handling the retry: %v", err.Error())
 					}
@@ -96,6 +163,61 @@ func (c *Consumer) Listen(ch *amqp.Channel) {
 	<-forever
 }
 
+// listenOrderCancelled remembers cancelled orders so their queued work is
+// dropped instead of cooked.
+func (c *Consumer) listenOrderCancelled(ch *amqp.Channel) {
+	q, err := ch.QueueDeclare("", true, false, true, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ch.QueueBind(q.Name, "", broker.OrderCancelledEvent, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for d := range msgs {
+		var o *pb.Order
+		if err := json.Unmarshal(d.Body, &o); err != nil {
+			log.Printf("Error unmarshalling order: %v", err)
+			d.Nack(false, false)
+			continue
+		}
+
+		c.markCancelled(o.ID)
+		log.Printf("Order %s cancelled", o.ID)
+
+		d.Ack(false)
+	}
+}
+
+func (c *Consumer) markCancelled(orderID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for id, at := range c.cancelled {
+		if now.Sub(at) > cancelledRetention {
+			delete(c.cancelled, id)
+		}
+	}
+
+	c.cancelled[orderID] = now
+}
+
+func (c *Consumer) isCancelled(orderID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.cancelled[orderID]
+	return ok
+}
+
 func cookOrder() {
 	log.Println("Cooking order...")
 	time.Sleep(5 * time.Second)
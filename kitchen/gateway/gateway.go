@@ -7,5 +7,9 @@ import (
 )
 
 type KitchenGateway interface {
+	// ClaimOrder moves a paid order to preparing. It fails with
+	// FailedPrecondition when the order is no longer paid, because it was
+	// cancelled or an earlier delivery of the same message claimed it.
+	ClaimOrder(ctx context.Context, orderID, customerID string) error
 	UpdateOrder(context.Context, *pb.Order) error
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"log"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/discovery"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Gateway struct {
@@ -16,6 +19,8 @@ func NewGateway(registry discovery.Registry) *Gateway {
 	return &Gateway{registry}
 }
 
+// UpdateOrder moves the order to o.Status, re-reading and retrying when the
+// order was updated concurrently.
 func (g *Gateway) UpdateOrder(ctx context.Context, o *pb.Order) error {
 	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
 	if err != nil {
@@ -25,6 +30,33 @@ func (g *Gateway) UpdateOrder(ctx context.Context, o *pb.Order) error {
 
 	ordersClient := pb.NewOrderServiceClient(conn)
 
-	_, err = ordersClient.UpdateOrder(ctx, o)
+	ctx = common.WithActor(ctx, "kitchen", "order "+o.Status)
+
+	_, err = common.UpdateOrderWithRetry(ctx, ordersClient, o.ID, o.CustomerID, func(current *pb.Order) error {
+		current.Status = o.Status
+		return nil
+	})
+	return err
+}
+
+func (g *Gateway) ClaimOrder(ctx context.Context, orderID, customerID string) error {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	ordersClient := pb.NewOrderServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "kitchen", "order preparing")
+
+	_, err = common.UpdateOrderWithRetry(ctx, ordersClient, orderID, customerID, func(current *pb.Order) error {
+		if current.Status != "paid" {
+			return status.Errorf(codes.FailedPrecondition, "order %s is %s", orderID, current.Status)
+		}
+
+		current.Status = "preparing"
+		return nil
+	})
 	return err
 }
@@ -24,6 +24,8 @@ var (
 	amqpPass    = common.EnvString("RABBITMQ_PASS", "guest")
 	amqpHost    = common.EnvString("RABBITMQ_HOST", "localhost")
 	amqpPort    = common.EnvString("RABBITMQ_PORT", "5672")
+	// the location whose orders this kitchen prepares
+	location = common.EnvString("KITCHEN_LOCATION", common.DefaultLocation)
 )
 
 func main() {
@@ -70,7 +72,7 @@ func main() {
 
 	gateway := gateway.NewGateway(registry)
 
-	consumer := NewConsumer(gateway)
+	consumer := NewConsumer(gateway, location)
 	go consumer.Listen(ch)
 
 	log.Println("GRPC Server Started at ", grpcAddr)
@@ -7,9 +7,12 @@ import (
 	"log"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/broker"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type consumer struct {
@@ -55,7 +58,19 @@ func (c *consumer) Listen(ch *amqp.Channel) {
 				continue
 			}
 
-			_, err := c.service.UpdateOrder(context.Background(), o)
+			// the publisher identifies itself through the AppId property
+			updateCtx := withChangeSource(ctx, d.AppId, "payment received")
+
+			_, err := c.updateOrderWithRetry(updateCtx, o.ID, o.CustomerID, func(current *pb.Order) {
+				current.Status = o.Status
+			})
+			if status.Code(err) == codes.FailedPrecondition {
+				// the order already moved past this status, retrying won't help
+				log.Printf("ignoring order update: %v", err)
+				messageSpan.End()
+				d.Ack(false)
+				continue
+			}
 			if err != nil {
 				log.Printf("failed to update order: %v", err)
 
@@ -76,3 +91,30 @@ func (c *consumer) Listen(ch *amqp.Channel) {
 
 	<-forever
 }
+
+// updateOrderWithRetry is the in-process counterpart of
+// common.UpdateOrderWithRetry: it re-reads the order whenever the versioned
+// update loses a race against another writer.
+func (c *consumer) updateOrderWithRetry(ctx context.Context, orderID, customerID string, mutate func(*pb.Order)) (*pb.Order, error) {
+	var err error
+
+	for attempt := 0; attempt < common.MaxOrderUpdateAttempts; attempt++ {
+		var o *pb.Order
+		o, err = c.service.GetOrder(ctx, &pb.GetOrderRequest{
+			OrderID:    orderID,
+			CustomerID: customerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		mutate(o)
+
+		o, err = c.service.UpdateOrder(ctx, o)
+		if status.Code(err) != codes.Aborted {
+			return o, err
+		}
+	}
+
+	return nil, err
+}
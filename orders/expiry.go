@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/commons/broker"
+	"github.com/rikughi/omsv2-orders/gateway"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const expiryBatchSize = 100
+
+// expiryScheduler expires orders that were not paid within the payment
+// window. Every orders instance runs one: the versioned update guarantees a
+// single instance wins for each order, the others just skip it.
+type expiryScheduler struct {
+	store         OrdersStore
+	stock         gateway.StockGateway
+	paymentWindow time.Duration
+	interval      time.Duration
+}
+
+func NewExpiryScheduler(store OrdersStore, stock gateway.StockGateway, paymentWindow, interval time.Duration) *expiryScheduler {
+	return &expiryScheduler{store, stock, paymentWindow, interval}
+}
+
+func (e *expiryScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.expireBatch(ctx)
+		}
+	}
+}
+
+func (e *expiryScheduler) expireBatch(ctx context.Context) {
+	deadline := time.Now().Add(-e.paymentWindow)
+
+	orders, err := e.store.ListUnpaid(ctx, deadline, expiryBatchSize)
+	if err != nil {
+		zap.L().Error("failed to list unpaid orders", zap.Error(err))
+		return
+	}
+
+	expired := 0
+	for _, o := range orders {
+		err := e.expire(ctx, o.ToProto())
+
+		switch status.Code(err) {
+		case codes.OK:
+			expired++
+		case codes.Aborted, codes.FailedPrecondition:
+			// another instance or a payment got to the order first
+		default:
+			zap.L().Error("failed to expire order", zap.String("orderID", o.ID.Hex()), zap.Error(err))
+		}
+	}
+
+	if expired > 0 {
+		zap.L().Info("expired unpaid orders", zap.Int("count", expired))
+	}
+}
+
+func (e *expiryScheduler) expire(ctx context.Context, o *pb.Order) error {
+	o.Status = StatusExpired
+
+	// the event describes the order as it is after the update
+	event := proto.Clone(o).(*pb.Order)
+	event.Version++
+
+	msg, err := NewOutboxMessage(ctx, broker.OrderExpiredEvent, "", event)
+	if err != nil {
+		return err
+	}
+
+	change := newStatusChange(withChangeSource(ctx, "orders", "payment window elapsed"))
+
+	if err := e.store.Update(ctx, o.ID, o, change, msg); err != nil {
+		return err
+	}
+
+	releaseReservation(ctx, e.stock, o, "order expired")
+	return nil
+}
@@ -2,10 +2,14 @@ package gateway
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/rikughi/commons/api"
 )
 
 type StockGateway interface {
-	CheckIfItemIsInStock(ctx context.Context, customerID string, items []*pb.ItemsWithQuantity) (bool, []*pb.Item, error)
+	CheckIfItemIsInStock(ctx context.Context, locationID string, items []*pb.ItemsWithQuantity) (*pb.CheckIfItemIsInStockResponse, error)
+	ReserveItems(ctx context.Context, orderID, locationID string, items []*pb.ItemsWithQuantity, ttl time.Duration) (*pb.Reservation, error)
+	CommitReservation(ctx context.Context, reservationID string) error
+	ReleaseReservation(ctx context.Context, reservationID, reason string) error
 }
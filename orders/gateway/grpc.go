@@ -3,7 +3,9 @@ package gateway
 import (
 	"context"
 	"log"
+	"time"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/discovery"
 )
@@ -16,7 +18,7 @@ func NewGateway(registry discovery.Registry) *Gateway {
 	return &Gateway{registry}
 }
 
-func (g *Gateway) CheckIfItemIsInStock(ctx context.Context, customerID string, items []*pb.ItemsWithQuantity) (bool, []*pb.Item, error) {
+func (g *Gateway) CheckIfItemIsInStock(ctx context.Context, locationID string, items []*pb.ItemsWithQuantity) (*pb.CheckIfItemIsInStockResponse, error) {
 	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
 	if err != nil {
 		log.Fatalf("Failed to dial server: %v", err)
@@ -25,9 +27,61 @@ func (g *Gateway) CheckIfItemIsInStock(ctx context.Context, customerID string, i
 
 	c := pb.NewStockServiceClient(conn)
 
-	res, err := c.CheckIfItemIsInStock(ctx, &pb.CheckIfItemIsInStockRequest{
-		Items: items,
+	return c.CheckIfItemIsInStock(ctx, &pb.CheckIfItemIsInStockRequest{
+		Items:      items,
+		LocationID: locationID,
 	})
+}
+
+func (g *Gateway) ReserveItems(ctx context.Context, orderID, locationID string, items []*pb.ItemsWithQuantity, ttl time.Duration) (*pb.Reservation, error) {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
 
-	return res.InStock, res.Items, err
+	ctx = common.WithActor(ctx, "orders", "held for checkout")
+
+	return c.ReserveItems(ctx, &pb.ReserveItemsRequest{
+		Items:      items,
+		TTLSeconds: int64(ttl.Seconds()),
+		OrderID:    orderID,
+		LocationID: locationID,
+	})
+}
+
+func (g *Gateway) CommitReservation(ctx context.Context, reservationID string) error {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "orders", "order paid")
+
+	_, err = c.CommitReservation(ctx, &pb.ReservationRequest{
+		ReservationID: reservationID,
+	})
+	return err
+}
+
+func (g *Gateway) ReleaseReservation(ctx context.Context, reservationID, reason string) error {
+	conn, err := discovery.ServiceConnection(context.Background(), "stock", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	c := pb.NewStockServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "orders", reason)
+
+	_, err = c.ReleaseReservation(ctx, &pb.ReservationRequest{
+		ReservationID: reservationID,
+	})
+	return err
 }
@@ -2,14 +2,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
 
-	amqp "github.com/rabbitmq/amqp091-go"
 	pb "github.com/rikughi/commons/api"
-	"github.com/rikughi/commons/broker"
-	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 )
 
@@ -17,13 +11,11 @@ type grpcHandler struct {
 	pb.UnimplementedOrderServiceServer
 
 	service OrdersService
-	channel *amqp.Channel
 }
 
-func NewGRPCHandler(grpcServer *grpc.Server, service OrdersService, channel *amqp.Channel) {
+func NewGRPCHandler(grpcServer *grpc.Server, service OrdersService) {
 	handler := &grpcHandler{
 		service: service,
-		channel: channel,
 	}
 	pb.RegisterOrderServiceServer(grpcServer, handler)
 }
@@ -32,44 +24,41 @@ func (h *grpcHandler) GetOrder(ctx context.Context, p *pb.GetOrderRequest) (*pb.
 	return h.service.GetOrder(ctx, p)
 }
 
+// CreateOrder stores the order along with its order.created event, the
+// outbox relay takes care of publishing it. Retried requests carrying an
+// idempotency key get the original order back.
 func (h *grpcHandler) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
-	q, err := h.channel.QueueDeclare(broker.OrderCreatedEvent, true, false, false, false, nil)
-	if err != nil {
-		log.Fatal(err)
-	}
+	ctx = withIncomingChangeSource(ctx)
 
-	tr := otel.Tracer("amqp")
-	amqpContext, messageSpan := tr.Start(ctx, fmt.Sprintf("AMQP - publish - %s", q.Name))
-	defer messageSpan.End()
-
-	items, err := h.service.ValidateOrder(amqpContext, p)
-	if err != nil {
-		return nil, err
+	o, err := h.service.ReplayOrder(ctx, p)
+	if err != nil || o != nil {
+		return o, err
 	}
 
-	o, err := h.service.CreateOrder(amqpContext, p, items)
+	items, reservation, err := h.service.ValidateOrder(ctx, p)
 	if err != nil {
 		return nil, err
 	}
 
-	marshalledOrder, err := json.Marshal(o)
-	if err != nil {
-		return nil, err
-	}
+	return h.service.CreateOrder(ctx, p, items, reservation)
+}
 
-	// inject the headers
-	headers := broker.InjectAMQPHeaders(amqpContext)
+func (h *grpcHandler) UpdateOrder(ctx context.Context, p *pb.Order) (*pb.Order, error) {
+	return h.service.UpdateOrder(withIncomingChangeSource(ctx), p)
+}
 
-	h.channel.PublishWithContext(amqpContext, "", q.Name, false, false, amqp.Publishing{
-		ContentType:  "application/json",
-		Body:         marshalledOrder,
-		DeliveryMode: amqp.Persistent,
-		Headers:      headers,
-	})
+func (h *grpcHandler) ListOrders(ctx context.Context, p *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	return h.service.ListOrders(ctx, p)
+}
 
-	return o, nil
+func (h *grpcHandler) CancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	return h.service.CancelOrder(withIncomingChangeSource(ctx), p)
 }
 
-func (h *grpcHandler) UpdateOrder(ctx context.Context, p *pb.Order) (*pb.Order, error) {
-	return h.service.UpdateOrder(ctx, p)
+func (h *grpcHandler) GetOrderHistory(ctx context.Context, p *pb.GetOrderRequest) (*pb.OrderHistory, error) {
+	return h.service.GetOrderHistory(ctx, p)
+}
+
+func (h *grpcHandler) WatchOrder(p *pb.WatchOrderRequest, stream pb.OrderService_WatchOrderServer) error {
+	return h.service.WatchOrder(stream.Context(), p, stream.Send)
 }
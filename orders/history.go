@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StatusChange is an entry of an order's audit trail.
+type StatusChange struct {
+	From    string    `bson:"from"`
+	To      string    `bson:"to"`
+	At      time.Time `bson:"at"`
+	Actor   string    `bson:"actor,omitempty"`
+	TraceID string    `bson:"traceID,omitempty"`
+	Reason  string    `bson:"reason,omitempty"`
+}
+
+func (c StatusChange) ToProto() *pb.OrderStatusChange {
+	return &pb.OrderStatusChange{
+		From:      c.From,
+		To:        c.To,
+		Timestamp: c.At.Unix(),
+		Actor:     c.Actor,
+		TraceID:   c.TraceID,
+		Reason:    c.Reason,
+	}
+}
+
+type changeSourceKey struct{}
+
+type changeSource struct {
+	actor  string
+	reason string
+}
+
+// withChangeSource records who is changing orders through ctx and why.
+func withChangeSource(ctx context.Context, actor, reason string) context.Context {
+	return context.WithValue(ctx, changeSourceKey{}, changeSource{actor, reason})
+}
+
+// withIncomingChangeSource picks up the actor and reason a gRPC caller set
+// with common.WithActor.
+func withIncomingChangeSource(ctx context.Context) context.Context {
+	actor, reason := common.ActorFromIncomingContext(ctx)
+	return withChangeSource(ctx, actor, reason)
+}
+
+// newStatusChange describes a change made within ctx. From and To are
+// filled in by the store, which knows the status it replaced.
+func newStatusChange(ctx context.Context) StatusChange {
+	c := StatusChange{At: time.Now()}
+
+	if src, ok := ctx.Value(changeSourceKey{}).(changeSource); ok {
+		c.Actor = src.actor
+		c.Reason = src.reason
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		c.TraceID = sc.TraceID().String()
+	}
+
+	return c
+}
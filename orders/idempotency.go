@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IdempotencyKeyTTL is how long a key is remembered after the order it
+// created. Retries after that create a new order.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+var ErrDuplicateIdempotencyKey = errors.New("idempotency key already used")
+
+// IdempotencyKey links a client supplied key to the order its first request created.
+type IdempotencyKey struct {
+	ID          string             `bson:"_id"`
+	RequestHash string             `bson:"requestHash"`
+	OrderID     primitive.ObjectID `bson:"orderID"`
+	CreatedAt   time.Time          `bson:"createdAt"`
+}
+
+// idempotencyKeyID scopes keys to a customer so two customers can never
+// collide on the same key.
+func idempotencyKeyID(customerID, key string) string {
+	return customerID + ":" + key
+}
+
+// hashCreateOrderRequest fingerprints the parts of the request that define
+// the order, ignoring item order and duplicated item lines.
+func hashCreateOrderRequest(p *pb.CreateOrderRequest) string {
+	quantities := make(map[string]int32)
+	for _, item := range p.Items {
+		quantities[item.ID] += item.Quantity
+	}
+
+	ids := make([]string, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", p.CustomerID)
+	fmt.Fprintf(h, "@%s\n", common.LocationOrDefault(p.LocationID))
+	for _, id := range ids {
+		fmt.Fprintf(h, "%s:%d\n", id, quantities[id])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func ErrIdempotencyKeyMismatch(key string) error {
+	return status.Errorf(codes.AlreadyExists, "idempotency key %q was already used for a different order", key)
+}
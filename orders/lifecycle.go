@@ -0,0 +1,76 @@
+package main
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	StatusPending        = "pending"
+	StatusWaitingPayment = "waiting_payment"
+	StatusPaid           = "paid"
+	StatusPreparing      = "preparing"
+	StatusReady          = "ready"
+	StatusCancelled      = "cancelled"
+	StatusExpired        = "expired"
+)
+
+// transitions lists the statuses an order may move to from each status.
+// Moving an order to the status it already has is always allowed so that
+// re-delivered messages and payment link refreshes are harmless. Paid orders
+// can be cancelled until the kitchen starts preparing them, the payments
+// service refunds them.
+var transitions = map[string][]string{
+	StatusPending:        {StatusWaitingPayment, StatusPaid, StatusCancelled, StatusExpired},
+	StatusWaitingPayment: {StatusPaid, StatusCancelled, StatusExpired},
+	StatusPaid:           {StatusPreparing, StatusCancelled},
+	StatusPreparing:      {StatusReady},
+	StatusReady:          {},
+	StatusCancelled:      {},
+	StatusExpired:        {},
+}
+
+func IsValidStatus(s string) bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsFinalStatus reports whether an order in this status can no longer change.
+func IsFinalStatus(s string) bool {
+	next, ok := transitions[s]
+	return ok && len(next) == 0
+}
+
+func CanTransition(from, to string) bool {
+	if !IsValidStatus(from) || !IsValidStatus(to) {
+		return false
+	}
+
+	if from == to {
+		return true
+	}
+
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowedFrom returns every status an order can be in to move to the given status.
+func allowedFrom(to string) []string {
+	from := make([]string, 0)
+	for s := range transitions {
+		if CanTransition(s, to) {
+			from = append(from, s)
+		}
+	}
+
+	return from
+}
+
+func ErrInvalidTransition(from, to string) error {
+	return status.Errorf(codes.FailedPrecondition, "order cannot move from %q to %q", from, to)
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusPending, StatusWaitingPayment, true},
+		{StatusPending, StatusPaid, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPending, StatusExpired, true},
+		{StatusWaitingPayment, StatusPaid, true},
+		{StatusWaitingPayment, StatusCancelled, true},
+		{StatusWaitingPayment, StatusWaitingPayment, true},
+		{StatusWaitingPayment, StatusPending, false},
+		{StatusPaid, StatusPreparing, true},
+		{StatusPaid, StatusPaid, true},
+		{StatusPaid, StatusCancelled, true},
+		{StatusPaid, StatusExpired, false},
+		{StatusPaid, StatusReady, false},
+		{StatusPreparing, StatusReady, true},
+		// the kitchen already started on it
+		{StatusPreparing, StatusCancelled, false},
+		{StatusReady, StatusPreparing, false},
+		{StatusCancelled, StatusPaid, false},
+		{StatusExpired, StatusPaid, false},
+		{StatusCancelled, StatusCancelled, true},
+		{"shipped", StatusReady, false},
+		{StatusPending, "shipped", false},
+		{"shipped", "shipped", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -34,16 +34,16 @@ func (s *LoggingMiddleware) UpdateOrder(ctx context.Context, o *pb.Order) (*pb.O
 	return s.next.UpdateOrder(ctx, o)
 }
 
-func (s *LoggingMiddleware) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item) (*pb.Order, error) {
+func (s *LoggingMiddleware) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item, reservation *pb.Reservation) (*pb.Order, error) {
 	start := time.Now()
 	defer func() {
 		zap.L().Info("CreateOrder", zap.Duration("took", time.Since(start)))
 	}()
 
-	return s.next.CreateOrder(ctx, p, items)
+	return s.next.CreateOrder(ctx, p, items, reservation)
 }
 
-func (s *LoggingMiddleware) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, error) {
+func (s *LoggingMiddleware) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, *pb.Reservation, error) {
 	start := time.Now()
 	defer func() {
 		zap.L().Info("ValidateOrder", zap.Duration("took", time.Since(start)))
@@ -51,3 +51,48 @@ func (s *LoggingMiddleware) ValidateOrder(ctx context.Context, p *pb.CreateOrder
 
 	return s.next.ValidateOrder(ctx, p)
 }
+
+func (s *LoggingMiddleware) ListOrders(ctx context.Context, p *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	start := time.Now()
+	defer func() {
+		zap.L().Info("ListOrders", zap.Duration("took", time.Since(start)))
+	}()
+
+	return s.next.ListOrders(ctx, p)
+}
+
+func (s *LoggingMiddleware) CancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	start := time.Now()
+	defer func() {
+		zap.L().Info("CancelOrder", zap.Duration("took", time.Since(start)))
+	}()
+
+	return s.next.CancelOrder(ctx, p)
+}
+
+func (s *LoggingMiddleware) ReplayOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
+	start := time.Now()
+	defer func() {
+		zap.L().Info("ReplayOrder", zap.Duration("took", time.Since(start)))
+	}()
+
+	return s.next.ReplayOrder(ctx, p)
+}
+
+func (s *LoggingMiddleware) GetOrderHistory(ctx context.Context, p *pb.GetOrderRequest) (*pb.OrderHistory, error) {
+	start := time.Now()
+	defer func() {
+		zap.L().Info("GetOrderHistory", zap.Duration("took", time.Since(start)))
+	}()
+
+	return s.next.GetOrderHistory(ctx, p)
+}
+
+func (s *LoggingMiddleware) WatchOrder(ctx context.Context, p *pb.WatchOrderRequest, send func(*pb.Order) error) error {
+	start := time.Now()
+	defer func() {
+		zap.L().Info("WatchOrder", zap.Duration("took", time.Since(start)))
+	}()
+
+	return s.next.WatchOrder(ctx, p, send)
+}
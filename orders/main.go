@@ -32,6 +32,10 @@ var (
 	mongoPass   = common.EnvString("MONGO_DB_PASS", "example")
 	mongoAddr   = common.EnvString("MONGO_DB_HOST", "localhost:27017")
 	jaegerAddr  = common.EnvString("JAEGER_ADDR", "localhost:4318")
+	taxRateBps  = common.EnvString("TAX_RATE_BPS", "0")
+	// unpaid orders older than the payment window are expired
+	paymentWindow  = common.EnvString("ORDER_PAYMENT_WINDOW", "30m")
+	expiryInterval = common.EnvString("ORDER_EXPIRY_INTERVAL", "1m")
 )
 
 func main() {
@@ -94,11 +98,50 @@ func main() {
 
 	gateway := gateway.NewGateway(registry)
 
-	store := NewStore(mongoClient)
-	svc := NewService(store, gateway)
+	mongoStore := NewStore(mongoClient)
+	if err := mongoStore.EnsureIndexes(ctx); err != nil {
+		logger.Fatal("failed to create indexes", zap.Error(err))
+	}
+
+	watchers := NewWatchHub()
+	store := NewWatchedStore(mongoStore, watchers)
+
+	taxRate, err := strconv.ParseInt(taxRateBps, 10, 64)
+	if err != nil {
+		logger.Fatal("invalid TAX_RATE_BPS", zap.Error(err))
+	}
+
+	window, err := time.ParseDuration(paymentWindow)
+	if err != nil {
+		logger.Fatal("invalid ORDER_PAYMENT_WINDOW", zap.Error(err))
+	}
+	interval, err := time.ParseDuration(expiryInterval)
+	if err != nil {
+		logger.Fatal("invalid ORDER_EXPIRY_INTERVAL", zap.Error(err))
+	}
+
+	// stock stays reserved until the order is expired, which happens up to
+	// one expiry interval after the payment window elapsed, plus some slack
+	reservationTTL := window + 2*interval
+
+	svc := NewService(store, gateway, taxRate, reservationTTL, watchers)
 	svcWithTelemetry := NewTelemetryMiddleware(svc)
 	svcWithLogging := NewLoggingMiddleware(svcWithTelemetry)
-	NewGRPCHandler(grpcServer, svcWithLogging, ch)
+	NewGRPCHandler(grpcServer, svcWithLogging)
+
+	// the relay puts its channel in confirm mode, it gets one of its own so
+	// the consumer's publishes don't compete for its confirms
+	relayCh, closeRelay := broker.Connect(amqpUser, amqpPass, amqpHost, amqpPort)
+	defer func() {
+		closeRelay()
+		relayCh.Close()
+	}()
+
+	relay := NewOutboxRelay(store, relayCh)
+	go relay.Run(ctx)
+
+	expiry := NewExpiryScheduler(store, gateway, window, interval)
+	go expiry.Run(ctx)
 
 	consumer := NewConsumer(svc)
 	go consumer.Listen(ch)
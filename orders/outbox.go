@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rikughi/commons/broker"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 100
+	// outboxLease is how long a relay owns a message it claimed; another relay
+	// instance may pick the message up once the lease runs out.
+	outboxLease = 30 * time.Second
+)
+
+// OutboxMessage is an event waiting to be published to the broker. It is
+// written in the same transaction as the order change it describes.
+type OutboxMessage struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty"`
+	Exchange    string                 `bson:"exchange"`
+	RoutingKey  string                 `bson:"routingKey"`
+	Body        []byte                 `bson:"body"`
+	Headers     map[string]interface{} `bson:"headers,omitempty"`
+	CreatedAt   time.Time              `bson:"createdAt"`
+	LockedUntil time.Time              `bson:"lockedUntil,omitempty"`
+	SentAt      time.Time              `bson:"sentAt,omitempty"`
+}
+
+// NewOutboxMessage marshals the payload and captures the trace context of
+// ctx so the trace continues once the relay publishes the message.
+func NewOutboxMessage(ctx context.Context, exchange, routingKey string, payload any) (OutboxMessage, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+
+	return OutboxMessage{
+		Exchange:   exchange,
+		RoutingKey: routingKey,
+		Body:       body,
+		Headers:    broker.InjectAMQPHeaders(ctx),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+type outboxRelay struct {
+	store   OrdersStore
+	channel *amqp.Channel
+	lag     metric.Float64Histogram
+}
+
+func NewOutboxRelay(store OrdersStore, channel *amqp.Channel) *outboxRelay {
+	lag, err := otel.Meter("orders").Float64Histogram(
+		"outbox.relay.lag",
+		metric.WithDescription("Time between an outbox message being stored and published"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		zap.L().Error("failed to create outbox lag histogram", zap.Error(err))
+	}
+
+	return &outboxRelay{store: store, channel: channel, lag: lag}
+}
+
+// Run publishes pending outbox messages until ctx is done. Messages are only
+// marked as sent once the broker confirmed them, so delivery is at least once.
+func (r *outboxRelay) Run(ctx context.Context) {
+	if err := r.channel.Confirm(false); err != nil {
+		zap.L().Fatal("failed to put channel in confirm mode", zap.Error(err))
+	}
+
+	// order.created is published through the default exchange straight to
+	// its queue, make sure the queue exists before relaying to it
+	if _, err := r.channel.QueueDeclare(broker.OrderCreatedEvent, true, false, false, false, nil); err != nil {
+		zap.L().Fatal("failed to declare queue", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relayBatch(ctx)
+		}
+	}
+}
+
+func (r *outboxRelay) relayBatch(ctx context.Context) {
+	var (
+		sent   int
+		maxLag time.Duration
+	)
+
+	for sent < outboxBatchSize {
+		m, err := r.store.ClaimOutboxMessage(ctx, outboxLease)
+		if err != nil {
+			zap.L().Error("failed to claim outbox message", zap.Error(err))
+			break
+		}
+		if m == nil {
+			break
+		}
+
+		if err := r.publish(ctx, m); err != nil {
+			zap.L().Error("failed to relay outbox message",
+				zap.String("id", m.ID.Hex()),
+				zap.String("routingKey", m.RoutingKey),
+				zap.Duration("lag", time.Since(m.CreatedAt)),
+				zap.Error(err))
+			break
+		}
+
+		if err := r.store.MarkOutboxMessageSent(ctx, m.ID); err != nil {
+			zap.L().Error("failed to mark outbox message sent", zap.String("id", m.ID.Hex()), zap.Error(err))
+			break
+		}
+
+		lag := time.Since(m.CreatedAt)
+		if lag > maxLag {
+			maxLag = lag
+		}
+		if r.lag != nil {
+			r.lag.Record(ctx, lag.Seconds())
+		}
+
+		sent++
+	}
+
+	if sent > 0 {
+		zap.L().Info("relayed outbox messages", zap.Int("count", sent), zap.Duration("maxLag", maxLag))
+	}
+}
+
+func (r *outboxRelay) publish(ctx context.Context, m *OutboxMessage) error {
+	name := m.Exchange
+	if name == "" {
+		name = m.RoutingKey
+	}
+
+	tr := otel.Tracer("amqp")
+	amqpContext, messageSpan := tr.Start(broker.ExtractAMQPHeader(ctx, m.Headers), fmt.Sprintf("AMQP - publish - %s", name))
+	defer messageSpan.End()
+
+	confirm, err := r.channel.PublishWithDeferredConfirmWithContext(amqpContext, m.Exchange, m.RoutingKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         m.Body,
+		DeliveryMode: amqp.Persistent,
+		Headers:      broker.InjectAMQPHeaders(amqpContext),
+	})
+	if err != nil {
+		return err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	acked, err := confirm.WaitContext(waitCtx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected message %s", m.ID.Hex())
+	}
+
+	return nil
+}
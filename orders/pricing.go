@@ -0,0 +1,43 @@
+package main
+
+import (
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Totals of an order, in the currency's minor unit.
+type Totals struct {
+	Subtotal int64
+	Tax      int64
+	Total    int64
+	Currency string
+}
+
+// priceItems fills in the line totals of the items and sums them up. Tax is
+// charged on the subtotal at taxRateBps basis points (1/100 of a percent),
+// rounded half up to the nearest minor unit.
+func priceItems(items []*pb.Item, taxRateBps int64) (Totals, error) {
+	var t Totals
+
+	for _, item := range items {
+		if item.UnitAmount <= 0 || item.Currency == "" {
+			return Totals{}, status.Errorf(codes.FailedPrecondition, "item %s has no price", item.ID)
+		}
+
+		if t.Currency == "" {
+			t.Currency = item.Currency
+		}
+		if item.Currency != t.Currency {
+			return Totals{}, status.Errorf(codes.FailedPrecondition, "item %s is priced in %s, the order is in %s", item.ID, item.Currency, t.Currency)
+		}
+
+		item.LineTotal = item.UnitAmount * int64(item.Quantity)
+		t.Subtotal += item.LineTotal
+	}
+
+	t.Tax = (t.Subtotal*taxRateBps + 5000) / 10000
+	t.Total = t.Subtotal + t.Tax
+
+	return t, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPriceItems(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []*pb.Item
+		taxRateBps int64
+		want       Totals
+		wantCode   codes.Code
+	}{
+		{
+			name:  "no tax",
+			items: []*pb.Item{{ID: "chips", Quantity: 3, UnitAmount: 250, Currency: "usd"}},
+			want:  Totals{Subtotal: 750, Tax: 0, Total: 750, Currency: "usd"},
+		},
+		{
+			name: "lines summed",
+			items: []*pb.Item{
+				{ID: "chips", Quantity: 2, UnitAmount: 250, Currency: "usd"},
+				{ID: "soda", Quantity: 1, UnitAmount: 199, Currency: "usd"},
+			},
+			taxRateBps: 1000,
+			// 69.9 rounds up
+			want: Totals{Subtotal: 699, Tax: 70, Total: 769, Currency: "usd"},
+		},
+		{
+			name:       "half a minor unit rounds up",
+			items:      []*pb.Item{{ID: "chips", Quantity: 1, UnitAmount: 50, Currency: "usd"}},
+			taxRateBps: 1000,
+			want:       Totals{Subtotal: 50, Tax: 5, Total: 55, Currency: "usd"},
+		},
+		{
+			// 8.75% of 1.30 is 0.11375
+			name:       "less than half rounds down",
+			items:      []*pb.Item{{ID: "chips", Quantity: 1, UnitAmount: 130, Currency: "usd"}},
+			taxRateBps: 875,
+			want:       Totals{Subtotal: 130, Tax: 11, Total: 141, Currency: "usd"},
+		},
+		{
+			// 8.75% of 0.06 is 0.00525
+			name:       "tax under a minor unit",
+			items:      []*pb.Item{{ID: "gum", Quantity: 1, UnitAmount: 6, Currency: "usd"}},
+			taxRateBps: 875,
+			want:       Totals{Subtotal: 6, Tax: 1, Total: 7, Currency: "usd"},
+		},
+		{
+			name:       "currency without a minor unit",
+			items:      []*pb.Item{{ID: "ramen", Quantity: 3, UnitAmount: 985, Currency: "jpy"}},
+			taxRateBps: 1000,
+			// 295.5 rounds up
+			want: Totals{Subtotal: 2955, Tax: 296, Total: 3251, Currency: "jpy"},
+		},
+		{
+			name: "mixed currencies",
+			items: []*pb.Item{
+				{ID: "chips", Quantity: 1, UnitAmount: 250, Currency: "usd"},
+				{ID: "soda", Quantity: 1, UnitAmount: 199, Currency: "eur"},
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "no price",
+			items:    []*pb.Item{{ID: "chips", Quantity: 1, Currency: "usd"}},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := priceItems(tt.items, tt.taxRateBps)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("error %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("priceItems() = %+v, want %+v", got, tt.want)
+			}
+			for _, item := range tt.items {
+				if item.LineTotal != item.UnitAmount*int64(item.Quantity) {
+					t.Errorf("%s line total %d, want %d", item.ID, item.LineTotal, item.UnitAmount*int64(item.Quantity))
+				}
+			}
+		})
+	}
+}
@@ -2,23 +2,46 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"time"
 
 	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/commons/broker"
 	"github.com/rikughi/omsv2-orders/gateway"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
 )
 
 type service struct {
 	store   OrdersStore
 	gateway gateway.StockGateway
+	// taxRateBps is the tax rate in basis points, 825 is 8.25%
+	taxRateBps int64
+	// reservationTTL is how long the stock of a new order is held
+	reservationTTL time.Duration
+	watchers       *watchHub
 }
 
-func NewService(store OrdersStore, gateway gateway.StockGateway) *service {
-	return &service{store, gateway}
+func NewService(store OrdersStore, gateway gateway.StockGateway, taxRateBps int64, reservationTTL time.Duration, watchers *watchHub) *service {
+	return &service{store, gateway, taxRateBps, reservationTTL, watchers}
 }
 
 func (s *service) GetOrder(ctx context.Context, p *pb.GetOrderRequest) (*pb.Order, error) {
 	o, err := s.store.Get(ctx, p.OrderID, p.CustomerID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", p.OrderID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -26,44 +49,137 @@ func (s *service) GetOrder(ctx context.Context, p *pb.GetOrderRequest) (*pb.Orde
 	return o.ToProto(), nil
 }
 
-func (s *service) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item) (*pb.Order, error) {
-	id, err := s.store.Create(ctx, Order{
-		CustomerID:  p.CustomerID,
-		Status:      "pending",
-		Items:       items,
-		PaymentLink: "",
-	})
+// CreateOrder stores the order the reservation ValidateOrder made was for.
+// The reservation is released if the order can't be stored.
+func (s *service) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item, reservation *pb.Reservation) (*pb.Order, error) {
+	reservationID := reservation.ID
+	unplaced := &pb.Order{ID: reservation.OrderID, ReservationID: reservationID}
+
+	totals, err := priceItems(items, s.taxRateBps)
 	if err != nil {
+		releaseReservation(ctx, s.gateway, unplaced, "order not placed")
 		return nil, err
 	}
 
+	id, err := primitive.ObjectIDFromHex(reservation.OrderID)
+	if err != nil {
+		releaseReservation(ctx, s.gateway, unplaced, "order not placed")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID %q", reservation.OrderID)
+	}
+	createdAt := time.Now()
+
 	o := &pb.Order{
-		ID:         id.Hex(),
-		CustomerID: p.CustomerID,
-		Status:     "pending",
-		Items:      items,
+		ID:            id.Hex(),
+		CustomerID:    p.CustomerID,
+		Status:        StatusPending,
+		Items:         items,
+		CreatedAt:     createdAt.Unix(),
+		Version:       1,
+		Subtotal:      totals.Subtotal,
+		Tax:           totals.Tax,
+		Total:         totals.Total,
+		Currency:      totals.Currency,
+		ReservationID: reservationID,
+		LocationID:    reservation.LocationID,
+	}
+
+	// order.created goes through the default exchange to its queue
+	event, err := NewOutboxMessage(ctx, "", broker.OrderCreatedEvent, o)
+	if err != nil {
+		releaseReservation(ctx, s.gateway, o, "order not placed")
+		return nil, err
+	}
+
+	initial := newStatusChange(ctx)
+	initial.To = StatusPending
+	initial.At = createdAt
+
+	var key *IdempotencyKey
+	if p.IdempotencyKey != "" {
+		key = &IdempotencyKey{
+			ID:          idempotencyKeyID(p.CustomerID, p.IdempotencyKey),
+			RequestHash: hashCreateOrderRequest(p),
+			CreatedAt:   createdAt,
+		}
+	}
+
+	_, err = s.store.Create(ctx, Order{
+		ID:            id,
+		CustomerID:    p.CustomerID,
+		Status:        StatusPending,
+		Items:         items,
+		PaymentLink:   "",
+		CreatedAt:     createdAt,
+		Version:       1,
+		History:       []StatusChange{initial},
+		Subtotal:      totals.Subtotal,
+		Tax:           totals.Tax,
+		Total:         totals.Total,
+		Currency:      totals.Currency,
+		ReservationID: reservationID,
+		LocationID:    reservation.LocationID,
+	}, key, event)
+	if errors.Is(err, ErrDuplicateIdempotencyKey) {
+		// a concurrent request with the same key won the race, its order
+		// holds the stock
+		releaseReservation(ctx, s.gateway, o, "duplicate order request")
+		return s.ReplayOrder(ctx, p)
+	}
+	if err != nil {
+		releaseReservation(ctx, s.gateway, o, "order not placed")
+		return nil, err
 	}
 
 	return o, nil
 }
 
-func (s *service) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, error) {
+func (s *service) ReplayOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
+	if p.IdempotencyKey == "" {
+		return nil, nil
+	}
+
+	key, err := s.store.GetIdempotencyKey(ctx, p.CustomerID, p.IdempotencyKey)
+	if err != nil || key == nil {
+		return nil, err
+	}
+
+	if key.RequestHash != hashCreateOrderRequest(p) {
+		return nil, ErrIdempotencyKeyMismatch(p.IdempotencyKey)
+	}
+
+	return s.GetOrder(ctx, &pb.GetOrderRequest{
+		OrderID:    key.OrderID.Hex(),
+		CustomerID: p.CustomerID,
+	})
+}
+
+func (s *service) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, *pb.Reservation, error) {
 	if len(p.Items) == 0 {
-		return nil, common.ErrNoItems
+		return nil, nil, status.Error(codes.InvalidArgument, common.ErrNoItems.Error())
 	}
 
 	mergedItems := mergeItemsQuantities(p.Items)
+	location := common.LocationOrDefault(p.LocationID)
 
-	// validate with the stock service
-	inStock, items, err := s.gateway.CheckIfItemIsInStock(ctx, p.CustomerID, mergedItems)
+	// validate with the stock service of the location preparing the order
+	res, err := s.gateway.CheckIfItemIsInStock(ctx, location, mergedItems)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if !inStock {
-		return items, common.ErrNoStock
+	if !res.InStock {
+		// the error details tell the customer which items are missing
+		return nil, nil, common.NewStockError(res.Availability)
 	}
 
-	return items, nil
+	// hold the items so they can't be sold twice while the order is paid.
+	// The order ID is picked now so the stock ledger can refer to it.
+	orderID := primitive.NewObjectID().Hex()
+	reservation, err := s.gateway.ReserveItems(ctx, orderID, location, mergedItems, s.reservationTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Items, reservation, nil
 }
 
 func mergeItemsQuantities(items []*pb.ItemsWithQuantity) []*pb.ItemsWithQuantity {
@@ -80,18 +196,241 @@ func mergeItemsQuantities(items []*pb.ItemsWithQuantity) []*pb.ItemsWithQuantity
 		}
 
 		if !found {
-			merged = append(merged, item)
+			// copy so merging never alters the caller's request
+			merged = append(merged, &pb.ItemsWithQuantity{
+				ID:       item.ID,
+				Quantity: item.Quantity,
+			})
 		}
 	}
 
 	return merged
 }
 
+// UpdateOrder moves an order to the status it is given. Orders can't be
+// cancelled or expired through it: that releases their stock and publishes
+// order.cancelled, which only CancelOrder and the expiry scheduler do.
 func (s *service) UpdateOrder(ctx context.Context, o *pb.Order) (*pb.Order, error) {
-	err := s.store.Update(ctx, o.ID, o)
+	if !IsValidStatus(o.Status) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown order status %q", o.Status)
+	}
+	if o.Status == StatusCancelled || o.Status == StatusExpired {
+		return nil, status.Errorf(codes.InvalidArgument, "orders can't be moved to %q with UpdateOrder", o.Status)
+	}
+
+	err := s.store.Update(ctx, o.ID, o, newStatusChange(ctx))
 	if err != nil {
 		return nil, err
 	}
 
+	o.Version++
+
+	// paid orders keep their stock for good. Committing is idempotent, so a
+	// retried update just commits again.
+	if o.Status == StatusPaid && o.ReservationID != "" {
+		if err := s.gateway.CommitReservation(ctx, o.ReservationID); err != nil {
+			return nil, err
+		}
+	}
+
 	return o, nil
 }
+
+// CancelOrder cancels an order on behalf of its customer. Orders the kitchen
+// already started preparing can no longer be cancelled, paid ones are
+// refunded by the payments service once it gets order.cancelled.
+func (s *service) CancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	var err error
+
+	for attempt := 0; attempt < common.MaxOrderUpdateAttempts; attempt++ {
+		var o *pb.Order
+		o, err = s.cancelOrder(ctx, p)
+		if status.Code(err) != codes.Aborted {
+			return o, err
+		}
+	}
+
+	return nil, err
+}
+
+func (s *service) cancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	o, err := s.GetOrder(ctx, &pb.GetOrderRequest{
+		OrderID:    p.OrderID,
+		CustomerID: p.CustomerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the stock of a paid order was committed, there is no hold to release
+	paid := o.Status == StatusPaid
+	o.Status = StatusCancelled
+
+	// the event describes the order as it is after the update
+	cancelled := proto.Clone(o).(*pb.Order)
+	cancelled.Version++
+
+	event, err := NewOutboxMessage(ctx, broker.OrderCancelledEvent, "", cancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	change := newStatusChange(ctx)
+	if p.Reason != "" {
+		change.Reason = p.Reason
+	}
+
+	if err := s.store.Update(ctx, o.ID, o, change, event); err != nil {
+		return nil, err
+	}
+
+	if !paid {
+		releaseReservation(ctx, s.gateway, cancelled, "order cancelled")
+	}
+
+	return cancelled, nil
+}
+
+// releaseReservation gives back the stock held for an order that won't be
+// paid. Failures are only logged, the stock service releases holds on its
+// own once they expire.
+func releaseReservation(ctx context.Context, stock gateway.StockGateway, o *pb.Order, reason string) {
+	if o.ReservationID == "" {
+		return
+	}
+
+	if err := stock.ReleaseReservation(ctx, o.ReservationID, reason); err != nil {
+		zap.L().Warn("failed to release stock reservation",
+			zap.String("orderID", o.ID),
+			zap.String("reservationID", o.ReservationID),
+			zap.Error(err))
+	}
+}
+
+func (s *service) GetOrderHistory(ctx context.Context, p *pb.GetOrderRequest) (*pb.OrderHistory, error) {
+	o, err := s.store.Get(ctx, p.OrderID, p.CustomerID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", p.OrderID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history := &pb.OrderHistory{
+		OrderID: o.ID.Hex(),
+		Changes: make([]*pb.OrderStatusChange, 0, len(o.History)),
+	}
+	for _, c := range o.History {
+		history.Changes = append(history.Changes, c.ToProto())
+	}
+
+	return history, nil
+}
+
+// WatchOrder sends the order every time its version moves past
+// p.SinceVersion, until the order reaches a final status or ctx is done.
+func (s *service) WatchOrder(ctx context.Context, p *pb.WatchOrderRequest, send func(*pb.Order) error) error {
+	// subscribe before reading the order so no update slips in between
+	updates, unsubscribe := s.watchers.Subscribe(p.OrderID)
+	defer unsubscribe()
+
+	o, err := s.GetOrder(ctx, &pb.GetOrderRequest{
+		OrderID:    p.OrderID,
+		CustomerID: p.CustomerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	last := p.SinceVersion
+	for {
+		if o.Version > last {
+			if err := send(o); err != nil {
+				return err
+			}
+			last = o.Version
+		}
+
+		if IsFinalStatus(o.Status) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case o = <-updates:
+		}
+	}
+}
+
+func (s *service) ListOrders(ctx context.Context, p *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	if p.CustomerID == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer ID is required")
+	}
+
+	pageSize := int(p.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	f := ListOrdersFilter{
+		CustomerID: p.CustomerID,
+		Statuses:   p.Statuses,
+		// fetch one extra order to know whether there is a next page
+		Limit: int64(pageSize + 1),
+	}
+
+	if p.CreatedAfter > 0 {
+		f.CreatedAfter = time.Unix(p.CreatedAfter, 0)
+	}
+	if p.CreatedBefore > 0 {
+		f.CreatedBefore = time.Unix(p.CreatedBefore, 0)
+	}
+
+	if p.PageToken != "" {
+		after, err := decodePageToken(p.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		f.After = after
+	}
+
+	orders, err := s.store.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListOrdersResponse{Orders: make([]*pb.Order, 0, len(orders))}
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		res.NextPageToken = encodePageToken(orders[pageSize-1].ID)
+	}
+
+	for _, o := range orders {
+		res.Orders = append(res.Orders, o.ToProto())
+	}
+
+	return res, nil
+}
+
+func encodePageToken(id primitive.ObjectID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+func decodePageToken(token string) (primitive.ObjectID, error) {
+	var id primitive.ObjectID
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return id, err
+	}
+	if len(b) != len(id) {
+		return id, primitive.ErrInvalidHex
+	}
+
+	copy(id[:], b)
+	return id, nil
+}
@@ -2,16 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	pb "github.com/rikughi/commons/api"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	DbName   = "orders"
-	CollName = "orders"
+	DbName                 = "orders"
+	CollName               = "orders"
+	OutboxCollName         = "outbox"
+	IdempotencyKeyCollName = "idempotency_keys"
 )
 
 type store struct {
@@ -36,25 +43,327 @@ func (s *store) Get(ctx context.Context, id, customerID string) (*Order, error)
 	return &o, err
 }
 
-func (s *store) Create(ctx context.Context, o Order) (primitive.ObjectID, error) {
+// Create inserts the order together with its idempotency key, when given,
+// and its outbox messages in a single transaction, so an order is never
+// stored without its events or vice versa. ErrDuplicateIdempotencyKey is
+// returned when another request already claimed the key.
+func (s *store) Create(ctx context.Context, o Order, key *IdempotencyKey, events ...OutboxMessage) (primitive.ObjectID, error) {
 	col := s.db.Database(DbName).Collection(CollName)
+	keys := s.db.Database(DbName).Collection(IdempotencyKeyCollName)
 
-	newOrder, err := col.InsertOne(ctx, o)
-	id := newOrder.InsertedID.(primitive.ObjectID)
-	return id, err
+	if o.ID.IsZero() {
+		o.ID = primitive.NewObjectID()
+	}
+
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if key != nil {
+			key.OrderID = o.ID
+			if _, err := keys.InsertOne(sc, key); err != nil {
+				return err
+			}
+		}
+
+		if _, err := col.InsertOne(sc, o); err != nil {
+			return err
+		}
+
+		return s.insertOutbox(sc, events)
+	})
+	if mongo.IsDuplicateKeyError(err) && key != nil {
+		return primitive.NilObjectID, ErrDuplicateIdempotencyKey
+	}
+
+	return o.ID, err
 }
 
-func (s *store) Update(ctx context.Context, id string, newOrder *pb.Order) error {
+// GetIdempotencyKey returns nil when the key was never used or has expired.
+func (s *store) GetIdempotencyKey(ctx context.Context, customerID, key string) (*IdempotencyKey, error) {
+	col := s.db.Database(DbName).Collection(IdempotencyKeyCollName)
+
+	var k IdempotencyKey
+	err := col.FindOne(ctx, bson.M{"_id": idempotencyKeyID(customerID, key)}).Decode(&k)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+// Update is a compare-and-swap on the order version: it only applies when
+// the stored order still has newOrder.Version and its status allows the
+// transition, and bumps the version when it does. Lost races are reported
+// as Aborted so callers can re-read and retry. When the status changes, the
+// change is appended to the order history with From and To filled in from
+// the stored order. The outbox messages are only stored when the update is
+// applied.
+func (s *store) Update(ctx context.Context, id string, newOrder *pb.Order, change StatusChange, events ...OutboxMessage) error {
 	col := s.db.Database(DbName).Collection(CollName)
 
-	oID, _ := primitive.ObjectIDFromHex(id)
+	oID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid order ID %q", id)
+	}
+
+	history := bson.M{"$ifNull": bson.A{"$history", bson.A{}}}
+	entry := bson.M{
+		"from":    "$status",
+		"to":      literal(newOrder.Status),
+		"at":      change.At,
+		"actor":   literal(change.Actor),
+		"traceID": literal(change.TraceID),
+		"reason":  literal(change.Reason),
+	}
+
+	// expressions within a single $set stage all see the document as it was
+	// before the update, so "$status" is the status being replaced
+	set := bson.M{
+		"status":  literal(newOrder.Status),
+		"version": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+		"history": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{"$status", literal(newOrder.Status)}},
+			history,
+			bson.M{"$concatArrays": bson.A{history, bson.A{entry}}},
+		}},
+	}
+	if newOrder.PaymentLink != "" {
+		set["paymentLink"] = literal(newOrder.PaymentLink)
+	}
+
+	var matched bool
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		res, err := col.UpdateOne(sc,
+			bson.M{
+				"_id":     oID,
+				"status":  bson.M{"$in": allowedFrom(newOrder.Status)},
+				"version": versionFilter(newOrder.Version),
+			},
+			bson.A{bson.M{"$set": set}})
+		if err != nil {
+			return err
+		}
+
+		matched = res.MatchedCount > 0
+		if !matched {
+			return nil
+		}
+
+		return s.insertOutbox(sc, events)
+	})
+	if err != nil {
+		return err
+	}
+
+	if matched {
+		return nil
+	}
+
+	var current Order
+	err = col.FindOne(ctx, bson.M{"_id": oID}).Decode(&current)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return status.Errorf(codes.NotFound, "order %s not found", id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.Version != newOrder.Version {
+		return status.Errorf(codes.Aborted, "order %s was modified concurrently, expected version %d but found %d", id, newOrder.Version, current.Version)
+	}
+
+	return ErrInvalidTransition(current.Status, newOrder.Status)
+}
+
+// literal keeps values such as "$5 off" from being read as field paths in
+// aggregation pipeline updates.
+func literal(v interface{}) bson.M {
+	return bson.M{"$literal": v}
+}
+
+// versionFilter matches the given version. Orders stored before versioning
+// was introduced have no version and are treated as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{nil, int64(0)}}
+	}
+
+	return version
+}
+
+func (s *store) List(ctx context.Context, f ListOrdersFilter) ([]*Order, error) {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	filter := bson.M{"customerID": f.CustomerID}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+
+	createdAt := bson.M{}
+	if !f.CreatedAfter.IsZero() {
+		createdAt["$gte"] = f.CreatedAfter
+	}
+	if !f.CreatedBefore.IsZero() {
+		createdAt["$lt"] = f.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		filter["createdAt"] = createdAt
+	}
+
+	if !f.After.IsZero() {
+		filter["_id"] = bson.M{"$lt": f.After}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(f.Limit)
+
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*Order, 0)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// ListUnpaid returns orders still waiting for a payment that were created
+// before the given time, oldest first.
+func (s *store) ListUnpaid(ctx context.Context, createdBefore time.Time, limit int64) ([]*Order, error) {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	cursor, err := col.Find(ctx,
+		bson.M{
+			"status":    bson.M{"$in": bson.A{StatusPending, StatusWaitingPayment}},
+			"createdAt": bson.M{"$lt": createdBefore},
+		},
+		options.Find().
+			SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+			SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*Order, 0)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// ClaimOutboxMessage leases the oldest unsent outbox message to the caller.
+// It returns nil when there is nothing to relay.
+func (s *store) ClaimOutboxMessage(ctx context.Context, lease time.Duration) (*OutboxMessage, error) {
+	col := s.db.Database(DbName).Collection(OutboxCollName)
+
+	now := time.Now()
+
+	var m OutboxMessage
+	err := col.FindOneAndUpdate(ctx,
+		bson.M{
+			"sentAt": bson.M{"$exists": false},
+			"$or": bson.A{
+				bson.M{"lockedUntil": bson.M{"$exists": false}},
+				bson.M{"lockedUntil": bson.M{"$lt": now}},
+			},
+		},
+		bson.M{"$set": bson.M{"lockedUntil": now.Add(lease)}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	).Decode(&m)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (s *store) MarkOutboxMessageSent(ctx context.Context, id primitive.ObjectID) error {
+	col := s.db.Database(DbName).Collection(OutboxCollName)
 
 	_, err := col.UpdateOne(ctx,
-		bson.M{"_id": oID},
-		bson.M{"$set": bson.M{
-			"paymentLink": newOrder.PaymentLink,
-			"status":      newOrder.Status,
-		}})
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"sentAt": time.Now()},
+			"$unset": bson.M{"lockedUntil": ""},
+		})
+
+	return err
+}
+
+func (s *store) insertOutbox(ctx context.Context, events []OutboxMessage) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	col := s.db.Database(DbName).Collection(OutboxCollName)
+
+	docs := make([]interface{}, 0, len(events))
+	for _, e := range events {
+		docs = append(docs, e)
+	}
+
+	_, err := col.InsertMany(ctx, docs)
+	return err
+}
+
+func (s *store) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := s.db.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
+
+// EnsureIndexes creates the indexes backing the order listing queries, the
+// expiry of unpaid orders, the outbox relay and the expiry of idempotency keys.
+func (s *store) EnsureIndexes(ctx context.Context) error {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// listings page on _id, the created range is filtered in the index
+		{Keys: bson.D{{Key: "customerID", Value: 1}, {Key: "_id", Value: -1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "customerID", Value: 1}, {Key: "status", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	outbox := s.db.Database(DbName).Collection(OutboxCollName)
+
+	_, err = outbox.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "sentAt", Value: 1}, {Key: "_id", Value: 1}}},
+		// sent messages are kept for a week to help debugging, then purged
+		{
+			Keys:    bson.D{{Key: "sentAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := s.db.Database(DbName).Collection(IdempotencyKeyCollName)
+
+	_, err = keys.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(IdempotencyKeyTTL.Seconds())),
+	})
 
 	return err
 }
@@ -30,16 +30,51 @@ func (s *TelemetryMiddleware) UpdateOrder(ctx context.Context, o *pb.Order) (*pb
 	return s.next.UpdateOrder(ctx, o)
 }
 
-func (s *TelemetryMiddleware) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item) (*pb.Order, error) {
+func (s *TelemetryMiddleware) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest, items []*pb.Item, reservation *pb.Reservation) (*pb.Order, error) {
 	span := trace.SpanFromContext(ctx)
-	span.AddEvent(fmt.Sprintf("CreateOrder: %v, items: %v", p, items))
+	span.AddEvent(fmt.Sprintf("CreateOrder: %v, items: %v, reservation: %v", p, items, reservation))
 
-	return s.next.CreateOrder(ctx, p, items)
+	return s.next.CreateOrder(ctx, p, items, reservation)
 }
 
-func (s *TelemetryMiddleware) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, error) {
+func (s *TelemetryMiddleware) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) ([]*pb.Item, *pb.Reservation, error) {
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent(fmt.Sprintf("ValidateOrder: %v", p))
 
 	return s.next.ValidateOrder(ctx, p)
 }
+
+func (s *TelemetryMiddleware) ListOrders(ctx context.Context, p *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(fmt.Sprintf("ListOrders: %v", p))
+
+	return s.next.ListOrders(ctx, p)
+}
+
+func (s *TelemetryMiddleware) CancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(fmt.Sprintf("CancelOrder: %v", p))
+
+	return s.next.CancelOrder(ctx, p)
+}
+
+func (s *TelemetryMiddleware) ReplayOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(fmt.Sprintf("ReplayOrder: %v", p))
+
+	return s.next.ReplayOrder(ctx, p)
+}
+
+func (s *TelemetryMiddleware) GetOrderHistory(ctx context.Context, p *pb.GetOrderRequest) (*pb.OrderHistory, error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(fmt.Sprintf("GetOrderHistory: %v", p))
+
+	return s.next.GetOrderHistory(ctx, p)
+}
+
+func (s *TelemetryMiddleware) WatchOrder(ctx context.Context, p *pb.WatchOrderRequest, send func(*pb.Order) error) error {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(fmt.Sprintf("WatchOrder: %v", p))
+
+	return s.next.WatchOrder(ctx, p, send)
+}
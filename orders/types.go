@@ -2,22 +2,49 @@ package main
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/rikughi/commons/api"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type OrdersService interface {
-	CreateOrder(context.Context, *pb.CreateOrderRequest, []*pb.Item) (*pb.Order, error)
-	ValidateOrder(context.Context, *pb.CreateOrderRequest) ([]*pb.Item, error)
+	CreateOrder(context.Context, *pb.CreateOrderRequest, []*pb.Item, *pb.Reservation) (*pb.Order, error)
+	// ValidateOrder prices the items and reserves them in stock for an order
+	// to be created with the reservation's order ID.
+	ValidateOrder(context.Context, *pb.CreateOrderRequest) ([]*pb.Item, *pb.Reservation, error)
 	GetOrder(context.Context, *pb.GetOrderRequest) (*pb.Order, error)
 	UpdateOrder(context.Context, *pb.Order) (*pb.Order, error)
+	ListOrders(context.Context, *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error)
+	CancelOrder(context.Context, *pb.CancelOrderRequest) (*pb.Order, error)
+	GetOrderHistory(context.Context, *pb.GetOrderRequest) (*pb.OrderHistory, error)
+	WatchOrder(ctx context.Context, p *pb.WatchOrderRequest, send func(*pb.Order) error) error
+	// ReplayOrder returns the order a previous request with the same
+	// idempotency key created, or nil if the request was not seen before.
+	ReplayOrder(context.Context, *pb.CreateOrderRequest) (*pb.Order, error)
 }
 
 type OrdersStore interface {
 	Get(ctx context.Context, id, customerID string) (*Order, error)
-	Create(context.Context, Order) (primitive.ObjectID, error)
-	Update(ctx context.Context, id string, o *pb.Order) error
+	Create(ctx context.Context, o Order, key *IdempotencyKey, events ...OutboxMessage) (primitive.ObjectID, error)
+	Update(ctx context.Context, id string, o *pb.Order, change StatusChange, events ...OutboxMessage) error
+	List(ctx context.Context, f ListOrdersFilter) ([]*Order, error)
+	ListUnpaid(ctx context.Context, createdBefore time.Time, limit int64) ([]*Order, error)
+	GetIdempotencyKey(ctx context.Context, customerID, key string) (*IdempotencyKey, error)
+	ClaimOutboxMessage(ctx context.Context, lease time.Duration) (*OutboxMessage, error)
+	MarkOutboxMessageSent(ctx context.Context, id primitive.ObjectID) error
+}
+
+// ListOrdersFilter narrows down a customer's orders. Results are ordered
+// newest first and After, when set, is the ID of the last order of the
+// previous page.
+type ListOrdersFilter struct {
+	CustomerID    string
+	Statuses      []string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	After         primitive.ObjectID
+	Limit         int64
 }
 
 type Order struct {
@@ -26,13 +53,41 @@ type Order struct {
 	Status      string             `bson:"status,omitempty"`
 	PaymentLink string             `bson:"paymentLink,omitempty"`
 	Items       []*pb.Item         `bson:"items,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt,omitempty"`
+	Version     int64              `bson:"version"`
+	History     []StatusChange     `bson:"history,omitempty"`
+	// amounts are in the currency's minor unit, e.g. cents
+	Subtotal int64  `bson:"subtotal"`
+	Tax      int64  `bson:"tax"`
+	Total    int64  `bson:"total"`
+	Currency string `bson:"currency,omitempty"`
+
+	// stock held for the order until it is paid, cancelled or expired
+	ReservationID string `bson:"reservationID,omitempty"`
+	// the kitchen preparing the order, always set when it is placed
+	LocationID string `bson:"locationID"`
 }
 
 func (o *Order) ToProto() *pb.Order {
+	// orders created before createdAt was stored fall back to the id timestamp
+	createdAt := o.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = o.ID.Timestamp()
+	}
+
 	return &pb.Order{
-		ID:          o.ID.Hex(),
-		CustomerID:  o.CustomerID,
-		Status:      o.Status,
-		PaymentLink: o.PaymentLink,
+		ID:            o.ID.Hex(),
+		CustomerID:    o.CustomerID,
+		Status:        o.Status,
+		PaymentLink:   o.PaymentLink,
+		Items:         o.Items,
+		CreatedAt:     createdAt.Unix(),
+		Version:       o.Version,
+		Subtotal:      o.Subtotal,
+		Tax:           o.Tax,
+		Total:         o.Total,
+		Currency:      o.Currency,
+		ReservationID: o.ReservationID,
+		LocationID:    o.LocationID,
 	}
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// watchHub fans order updates out to the WatchOrder streams of this
+// instance. Subscribers only care about the latest state, so a slow
+// subscriber gets intermediate updates replaced instead of blocking writers.
+type watchHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan *pb.Order]struct{}
+}
+
+func NewWatchHub() *watchHub {
+	return &watchHub{subs: make(map[string]map[chan *pb.Order]struct{})}
+}
+
+// Subscribe returns the updates of an order and a func to stop receiving them.
+func (h *watchHub) Subscribe(orderID string) (<-chan *pb.Order, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *pb.Order, 1)
+	if _, ok := h.subs[orderID]; !ok {
+		h.subs[orderID] = make(map[chan *pb.Order]struct{})
+	}
+	h.subs[orderID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subs[orderID], ch)
+		if len(h.subs[orderID]) == 0 {
+			delete(h.subs, orderID)
+		}
+	}
+}
+
+func (h *watchHub) Publish(o *pb.Order) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs[o.ID] {
+		// drop the pending update, if any, in favour of the newer one
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- proto.Clone(o).(*pb.Order)
+	}
+}
+
+// watchedStore publishes every applied order update to the hub, whichever
+// part of the service made it.
+type watchedStore struct {
+	OrdersStore
+	hub *watchHub
+}
+
+func NewWatchedStore(store OrdersStore, hub *watchHub) *watchedStore {
+	return &watchedStore{store, hub}
+}
+
+func (s *watchedStore) Update(ctx context.Context, id string, o *pb.Order, change StatusChange, events ...OutboxMessage) error {
+	if err := s.OrdersStore.Update(ctx, id, o, change, events...); err != nil {
+		return err
+	}
+
+	updated := proto.Clone(o).(*pb.Order)
+	updated.ID = id
+	updated.Version++
+	s.hub.Publish(updated)
+
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/broker"
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type consumer struct {
@@ -21,6 +23,8 @@ func NewConsumer(service PaymentsService) *consumer {
 }
 
 func (c *consumer) Listen(ch *amqp.Channel) {
+	go c.listenOrderClosed(ch)
+
 	q, err := ch.QueueDeclare(broker.OrderCreatedEvent, true, false, false, false, nil)
 	if err != nil {
 		log.Fatal(err)
@@ -48,7 +52,13 @@ func (c *consumer) Listen(ch *amqp.Channel) {
 				continue
 			}
 
-			paymentLink, err := c.service.CreatePayment(context.Background(), o)
+			payment, err := c.service.CreatePayment(context.Background(), o)
+			if status.Code(err) == codes.FailedPrecondition {
+				log.Printf("order %s can no longer be paid: %v", o.ID, err)
+				messageSpan.End()
+				d.Ack(false)
+				continue
+			}
 			if err != nil {
 				log.Printf("failed to create payment: %v", err)
 
@@ -61,13 +71,69 @@ func (c *consumer) Listen(ch *amqp.Channel) {
 				continue
 			}
 
-			messageSpan.AddEvent(fmt.Sprintf("payment.created: %s", paymentLink))
+			messageSpan.AddEvent(fmt.Sprintf("payment.created: %s", payment.Link))
 			messageSpan.End()
 
-			log.Printf("Payment link created %s", paymentLink)
+			log.Printf("Payment link created %s", payment.Link)
 			d.Ack(false)
 		}
 	}()
 
 	<-forever
 }
+
+// listenOrderClosed expires the checkout session of cancelled and expired
+// orders so the customer can no longer pay for them, and refunds cancelled
+// orders that were already paid.
+func (c *consumer) listenOrderClosed(ch *amqp.Channel) {
+	q, err := ch.QueueDeclare("", true, false, true, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, exchange := range []string{broker.OrderCancelledEvent, broker.OrderExpiredEvent} {
+		err = ch.QueueBind(q.Name, "", exchange, false, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for d := range msgs {
+		ctx := broker.ExtractAMQPHeader(context.Background(), d.Headers)
+
+		tr := otel.Tracer("amqp")
+		_, messageSpan := tr.Start(ctx, fmt.Sprintf("AMQP - consume - %s", d.Exchange))
+
+		o := &pb.Order{}
+		if err := json.Unmarshal(d.Body, o); err != nil {
+			d.Nack(false, false)
+			log.Printf("failed to unmarshal order: %v", err)
+			messageSpan.End()
+			continue
+		}
+
+		// only failed refunds are returned, a retry skips the payments that
+		// were refunded already
+		if err := c.service.CancelPayment(context.Background(), o); err != nil {
+			log.Printf("failed to cancel payment for order %s: %v", o.ID, err)
+
+			if err := broker.HandleRetry(ch, &d); err != nil {
+				log.Printf("Error handling retry: %v", err)
+			}
+
+			d.Nack(false, false)
+			messageSpan.End()
+			continue
+		}
+
+		messageSpan.AddEvent(fmt.Sprintf("payment.cancelled: %s", o.ID))
+		messageSpan.End()
+
+		d.Ack(false)
+	}
+}
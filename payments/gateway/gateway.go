@@ -1,7 +1,12 @@
 package gateway
 
-import "context"
+import (
+	"context"
+
+	pb "github.com/rikughi/commons/api"
+)
 
 type OrdersGateway interface {
-	UpdateOrderAfterPaymentLink(ctx context.Context, orderID, paymentLink string) error
+	GetOrder(ctx context.Context, orderID, customerID string) (*pb.Order, error)
+	UpdateOrderAfterPaymentLink(ctx context.Context, orderID, customerID, paymentLink string) error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/discovery"
 )
@@ -16,7 +17,7 @@ func NewGateway(registry discovery.Registry) *gateway {
 	return &gateway{registry}
 }
 
-func (g *gateway) UpdateOrderAfterPaymentLink(ctx context.Context, orderID, paymentLink string) error {
+func (g *gateway) GetOrder(ctx context.Context, orderID, customerID string) (*pb.Order, error) {
 	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
 	if err != nil {
 		log.Fatalf("Failed to dial server: %v", err)
@@ -25,10 +26,24 @@ func (g *gateway) UpdateOrderAfterPaymentLink(ctx context.Context, orderID, paym
 
 	ordersClient := pb.NewOrderServiceClient(conn)
 
-	_, err = ordersClient.UpdateOrder(ctx, &pb.Order{
-		ID:          orderID,
-		Status:      "waiting_payment",
-		PaymentLink: paymentLink,
+	return ordersClient.GetOrder(ctx, &pb.GetOrderRequest{OrderID: orderID, CustomerID: customerID})
+}
+
+func (g *gateway) UpdateOrderAfterPaymentLink(ctx context.Context, orderID, customerID, paymentLink string) error {
+	conn, err := discovery.ServiceConnection(context.Background(), "orders", g.registry)
+	if err != nil {
+		log.Fatalf("Failed to dial server: %v", err)
+	}
+	defer conn.Close()
+
+	ordersClient := pb.NewOrderServiceClient(conn)
+
+	ctx = common.WithActor(ctx, "payments", "payment link created")
+
+	_, err = common.UpdateOrderWithRetry(ctx, ordersClient, orderID, customerID, func(o *pb.Order) error {
+		o.Status = "waiting_payment"
+		o.PaymentLink = paymentLink
+		return nil
 	})
 	return err
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc"
+)
+
+type grpcHandler struct {
+	pb.UnimplementedPaymentServiceServer
+
+	service PaymentsService
+}
+
+func NewGRPCHandler(grpcServer *grpc.Server, service PaymentsService) {
+	handler := &grpcHandler{
+		service: service,
+	}
+	pb.RegisterPaymentServiceServer(grpcServer, handler)
+}
+
+func (h *grpcHandler) GetPayment(ctx context.Context, p *pb.GetPaymentRequest) (*pb.Payment, error) {
+	payment, err := h.service.GetPayment(ctx, p.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment.ToProto(), nil
+}
+
+func (h *grpcHandler) CreatePaymentLink(ctx context.Context, p *pb.CreatePaymentLinkRequest) (*pb.Payment, error) {
+	payment, err := h.service.CreatePaymentLink(ctx, p.OrderID, p.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment.ToProto(), nil
+}
+
+func (h *grpcHandler) ListPaymentsForOrder(ctx context.Context, p *pb.ListPaymentsForOrderRequest) (*pb.ListPaymentsForOrderResponse, error) {
+	payments, err := h.service.ListPaymentsForOrder(ctx, p.OrderID, p.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListPaymentsForOrderResponse{Payments: make([]*pb.Payment, 0, len(payments))}
+	for _, payment := range payments {
+		res.Payments = append(res.Payments, payment.ToProto())
+	}
+
+	return res, nil
+}
+
+func (h *grpcHandler) RefundPayment(ctx context.Context, p *pb.RefundPaymentRequest) (*pb.Payment, error) {
+	payment, err := h.service.RefundPayment(ctx, p.PaymentID, p.Amount, p.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment.ToProto(), nil
+}
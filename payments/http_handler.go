@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,6 +14,7 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/commons/broker"
+	"github.com/rikughi/omsv2-payments/processor"
 	"github.com/stripe/stripe-go/v78"
 	"github.com/stripe/stripe-go/v78/webhook"
 	"go.opentelemetry.io/otel"
@@ -20,10 +22,11 @@ import (
 
 type PaymentHTTPHandler struct {
 	channel *amqp.Channel
+	service PaymentsService
 }
 
-func NewPaymentHTTPHandler(channel *amqp.Channel) *PaymentHTTPHandler {
-	return &PaymentHTTPHandler{channel}
+func NewPaymentHTTPHandler(channel *amqp.Channel, service PaymentsService) *PaymentHTTPHandler {
+	return &PaymentHTTPHandler{channel, service}
 }
 
 func (h *PaymentHTTPHandler) registerRoutes(router *http.ServeMux) {
@@ -49,53 +52,98 @@ func (h *PaymentHTTPHandler) handleCheckoutWebhook(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if event.Type == "checkout.session.completed" {
-		var session stripe.CheckoutSession
-		err := json.Unmarshal(event.Data.Raw, &session)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing webhook JSON: %v\n", err)
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		if session.PaymentStatus == "paid" {
-			log.Printf("Payment for Checkout Session %v succeeded!", session.ID)
-
-			orderID := session.Metadata["orderID"]
-			customerID := session.Metadata["customerID"]
-
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			o := &pb.Order{
-				ID:          orderID,
-				CustomerID:  customerID,
-				Status:      "paid",
-				PaymentLink: "",
-			}
-
-			marshalledOrder, err := json.Marshal(o)
-			if err != nil {
-				log.Fatal(err.Error())
-			}
-
-			tr := otel.Tracer("amqp")
-			amqpContext, messageSpan := tr.Start(ctx, fmt.Sprintf("AMQP - publish - %s", broker.OrderPaidEvent))
-			defer messageSpan.End()
-
-			headers := broker.InjectAMQPHeaders(amqpContext)
-
-			// publish a message
-			h.channel.PublishWithContext(amqpContext, broker.OrderPaidEvent, "", false, false, amqp.Publishing{
-				ContentType:  "application/json",
-				Body:         marshalledOrder,
-				DeliveryMode: amqp.Persistent,
-				Headers:      headers,
-			})
-
-			log.Println("Message published order.paid")
-		}
+	var to, reason string
+	switch event.Type {
+	case "checkout.session.completed":
+		to, reason = processor.StatusProcessing, "checkout completed, waiting for the payment"
+	case "checkout.session.async_payment_succeeded":
+		to, reason = processor.StatusPaid, "payment succeeded"
+	case "checkout.session.async_payment_failed":
+		to, reason = processor.StatusFailed, "payment failed"
+	case "checkout.session.expired":
+		to, reason = processor.StatusExpired, "checkout session expired"
+	default:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var session stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing webhook JSON: %v\n", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if event.Type == "checkout.session.completed" && session.PaymentStatus == "paid" {
+		to, reason = processor.StatusPaid, "checkout completed"
+	}
+
+	payment, changed, err := h.service.RecordPaymentStatus(ctx, session.ID, to, reason)
+	switch {
+	case errors.Is(err, ErrPaymentNotFound):
+		// sessions created before payments were recorded
+		log.Printf("No payment recorded for Checkout Session %v", session.ID)
+	case err != nil:
+		// the provider retries webhooks that fail
+		log.Printf("Failed to record the status of Checkout Session %v: %v", session.ID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	case !changed:
+		log.Printf("Payment for Checkout Session %v was already recorded", session.ID)
+	}
+
+	if to != processor.StatusPaid || (payment != nil && payment.Status != processor.StatusPaid) {
+		w.WriteHeader(http.StatusOK)
+		return
 	}
 
+	// order.paid is published again for redelivered webhooks, in case an
+	// earlier delivery recorded the payment but failed to publish it
+	log.Printf("Payment for Checkout Session %v succeeded!", session.ID)
+
+	orderID := session.Metadata["orderID"]
+	customerID := session.Metadata["customerID"]
+	// the kitchens only pick up the orders of their location
+	locationID := session.Metadata["locationID"]
+
+	o := &pb.Order{
+		ID:          orderID,
+		CustomerID:  customerID,
+		Status:      "paid",
+		PaymentLink: "",
+		LocationID:  locationID,
+	}
+
+	marshalledOrder, err := json.Marshal(o)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	tr := otel.Tracer("amqp")
+	amqpContext, messageSpan := tr.Start(ctx, fmt.Sprintf("AMQP - publish - %s", broker.OrderPaidEvent))
+	defer messageSpan.End()
+
+	headers := broker.InjectAMQPHeaders(amqpContext)
+
+	// publish a message
+	err = h.channel.PublishWithContext(amqpContext, broker.OrderPaidEvent, "", false, false, amqp.Publishing{
+		AppId:        "payments",
+		ContentType:  "application/json",
+		Body:         marshalledOrder,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+	})
+	if err != nil {
+		// failing the webhook has the provider deliver it again
+		log.Printf("Failed to publish order.paid for Checkout Session %v: %v", session.ID, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	log.Println("Message published order.paid")
+
 	w.WriteHeader(http.StatusOK)
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -14,8 +15,13 @@ import (
 	"github.com/rikughi/commons/discovery"
 	"github.com/rikughi/commons/discovery/consul"
 	"github.com/rikughi/omsv2-payments/gateway"
+	"github.com/rikughi/omsv2-payments/processor"
+	fakeProcessor "github.com/rikughi/omsv2-payments/processor/fake"
 	stripeProcessor "github.com/rikughi/omsv2-payments/processor/stripe"
 	"github.com/stripe/stripe-go/v78"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"google.golang.org/grpc"
 )
 
@@ -30,6 +36,12 @@ var (
 	stripeKey            = common.EnvString("STRIPE_KEY", "sk_test")
 	endpointStripeSecret = common.EnvString("STRIPE_ENDPOINT_SECRET", "whsec")
 	httpAddr             = common.EnvString("HTTP_ADDR", "localhost:8081")
+	// stripe, or fake to pay on a local checkout page without network or keys
+	processorKind = common.EnvString("PAYMENT_PROCESSOR", "stripe")
+	// payment attempts are recorded in mongo
+	mongoUser = common.EnvString("MONGO_DB_USER", "root")
+	mongoPass = common.EnvString("MONGO_DB_PASS", "example")
+	mongoAddr = common.EnvString("MONGO_DB_HOST", "localhost:27017")
 )
 
 func main() {
@@ -66,17 +78,27 @@ func main() {
 		ch.Close()
 	}()
 
-	stripe.Key = stripeKey
-	stripeProcessor := stripeProcessor.NewProcessor()
+	uri := fmt.Sprintf("mongodb://%s:%s@%s", mongoUser, mongoPass, mongoAddr)
+	mongoClient, err := connectToMongoDB(uri)
+	if err != nil {
+		log.Fatalf("failed to connect to mongo db: %v", err)
+	}
+
+	store := NewStore(mongoClient)
+	if err := store.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to create the payments indexes: %v", err)
+	}
+
+	mux := http.NewServeMux()
+
+	paymentProcessor := newPaymentProcessor(mux)
 	gateway := gateway.NewGateway(registry)
-	svc := NewService(stripeProcessor, gateway)
+	svc := NewService(paymentProcessor, gateway, store)
 
 	amqpConsumer := NewConsumer(svc)
 	go amqpConsumer.Listen(ch)
 
-	mux := http.NewServeMux()
-
-	httpServer := NewPaymentHTTPHandler(ch)
+	httpServer := NewPaymentHTTPHandler(ch, svc)
 	httpServer.registerRoutes(mux)
 
 	go func() {
@@ -94,9 +116,41 @@ func main() {
 	}
 	defer l.Close()
 
+	NewGRPCHandler(grpcServer, svc)
+
 	log.Println("GRPC Server Started at ", grpcAddr)
 
 	if err := grpcServer.Serve(l); err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// newPaymentProcessor returns the processor selected by PAYMENT_PROCESSOR.
+// The fake one serves its checkout page on mux and calls our own webhook.
+func newPaymentProcessor(mux *http.ServeMux) processor.PaymentProcessor {
+	switch processorKind {
+	case "stripe":
+		stripe.Key = stripeKey
+		return stripeProcessor.NewProcessor()
+	case "fake":
+		base := "http://" + httpAddr
+		p := fakeProcessor.NewProcessor(base, base+"/webhook", endpointStripeSecret)
+		p.RegisterRoutes(mux)
+		return p
+	default:
+		log.Fatalf("unknown payment processor %q", processorKind)
+		return nil
+	}
+}
+
+func connectToMongoDB(uri string) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, readpref.Primary())
+	return client, err
+}
@@ -0,0 +1,388 @@
+// Package fake is a payment processor that needs neither network nor
+// provider keys. It serves its own checkout page, where the developer pays,
+// fails or cancels the payment, and reports the outcome to the webhook with
+// the events and signature Stripe would send.
+package fake
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/omsv2-payments/processor"
+	"github.com/stripe/stripe-go/v78"
+	"github.com/stripe/stripe-go/v78/webhook"
+)
+
+// sessions expire after a day, like Stripe checkout sessions
+const sessionTTL = 24 * time.Hour
+
+var gatewayHTTPAddr = common.EnvString("GATEWAY_HTTP_ADDRESS", "http://localhost:8080")
+
+// Fake keeps its checkout sessions in memory, they are lost on restart.
+type Fake struct {
+	// baseURL is where the checkout page is served
+	baseURL       string
+	webhookURL    string
+	webhookSecret string
+	client        *http.Client
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// session is a checkout session along with what the fake keeps track of
+// about it.
+type session struct {
+	checkout stripe.CheckoutSession
+	// refunded is the amount given back of a paid session
+	refunded int64
+	// refunds holds the idempotency keys of the refunds made
+	refunds map[string]bool
+	// busy is set while the webhook is told about an action on the checkout
+	// page, the session only changes once it took it
+	busy bool
+}
+
+// NewProcessor returns a processor serving its checkout page at baseURL,
+// which reports to the webhook at webhookURL signing with webhookSecret.
+func NewProcessor(baseURL, webhookURL, webhookSecret string) *Fake {
+	return &Fake{
+		baseURL:       baseURL,
+		webhookURL:    webhookURL,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		sessions:      make(map[string]*session),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreatePaymentLink(o *pb.Order) (*processor.Payment, error) {
+	log.Printf("Creating fake payment link for order %s", o.ID)
+
+	id, err := newID("cs_fake_")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sess := &session{checkout: stripe.CheckoutSession{
+		ID:     id,
+		Object: "checkout.session",
+		Metadata: map[string]string{
+			"orderID":    o.ID,
+			"customerID": o.CustomerID,
+			"locationID": o.LocationID,
+		},
+		AmountTotal:   o.Total,
+		Currency:      stripe.Currency(o.Currency),
+		Status:        stripe.CheckoutSessionStatusOpen,
+		PaymentStatus: stripe.CheckoutSessionPaymentStatusUnpaid,
+		URL:           fmt.Sprintf("%s/checkout/%s", f.baseURL, id),
+		SuccessURL:    fmt.Sprintf("%s/success.html?customerID=%s&orderID=%s", gatewayHTTPAddr, o.CustomerID, o.ID),
+		CancelURL:     fmt.Sprintf("%s/cancel.html", gatewayHTTPAddr),
+		Created:       now.Unix(),
+		ExpiresAt:     now.Add(sessionTTL).Unix(),
+	}}
+
+	f.mu.Lock()
+	f.sessions[id] = sess
+	f.mu.Unlock()
+
+	return sess.payment(), nil
+}
+
+func (f *Fake) ExpirePayment(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sess, ok := f.sessions[id]
+	if !ok {
+		return processor.ErrPaymentNotFound
+	}
+
+	cs := &sess.checkout
+	if cs.Status != stripe.CheckoutSessionStatusOpen {
+		return fmt.Errorf("checkout session %s is %s", id, cs.Status)
+	}
+	if sess.busy {
+		return fmt.Errorf("checkout session %s is being completed", id)
+	}
+
+	log.Printf("Expiring fake checkout session %s", id)
+
+	cs.Status = stripe.CheckoutSessionStatusExpired
+	return nil
+}
+
+// RefundPayment only keeps track of the amount refunded.
+func (f *Fake) RefundPayment(id string, amount int64, reason, idempotencyKey string) (*processor.Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sess, ok := f.sessions[id]
+	if !ok {
+		return nil, processor.ErrPaymentNotFound
+	}
+
+	if sess.refunds[idempotencyKey] {
+		return sess.payment(), nil
+	}
+
+	cs := &sess.checkout
+	if cs.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+		return nil, fmt.Errorf("checkout session %s has no payment to refund", id)
+	}
+	if sess.refunded+amount > cs.AmountTotal {
+		return nil, fmt.Errorf("only %d of checkout session %s is left to refund", cs.AmountTotal-sess.refunded, id)
+	}
+
+	log.Printf("Refunding %d of fake checkout session %s: %s", amount, id, reason)
+
+	if sess.refunds == nil {
+		sess.refunds = make(map[string]bool)
+	}
+	sess.refunds[idempotencyKey] = true
+	sess.refunded += amount
+	return sess.payment(), nil
+}
+
+func (s *session) payment() *processor.Payment {
+	cs := &s.checkout
+	p := &processor.Payment{
+		ID:             cs.ID,
+		OrderID:        cs.Metadata["orderID"],
+		CustomerID:     cs.Metadata["customerID"],
+		Link:           cs.URL,
+		Amount:         cs.AmountTotal,
+		AmountRefunded: s.refunded,
+		Currency:       string(cs.Currency),
+		CreatedAt:      time.Unix(cs.Created, 0),
+		ExpiresAt:      time.Unix(cs.ExpiresAt, 0),
+	}
+
+	switch {
+	case cs.Status == stripe.CheckoutSessionStatusOpen:
+		p.Status = processor.StatusOpen
+	case cs.Status == stripe.CheckoutSessionStatusExpired:
+		p.Status = processor.StatusExpired
+	case cs.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid:
+		p.Status = processor.StatusFailed
+	case s.refunded >= cs.AmountTotal && s.refunded > 0:
+		p.Status = processor.StatusRefunded
+	case s.refunded > 0:
+		p.Status = processor.StatusPartiallyRefunded
+	default:
+		p.Status = processor.StatusPaid
+	}
+
+	return p
+}
+
+// RegisterRoutes serves the checkout page the payment links point to.
+func (f *Fake) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /checkout/{id}", f.handleCheckout)
+	mux.HandleFunc("POST /checkout/{id}/{action}", f.handleAction)
+}
+
+var checkoutPage = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Fake checkout</title></head>
+<body>
+  <h1>Fake checkout</h1>
+  <p>Order {{.Order}}, {{.Amount}} {{.Currency}}</p>
+  {{if .Open}}
+  <form method="post" action="/checkout/{{.ID}}/pay"><button>Pay</button></form>
+  <form method="post" action="/checkout/{{.ID}}/fail"><button>Fail</button></form>
+  <form method="post" action="/checkout/{{.ID}}/cancel"><button>Cancel</button></form>
+  {{else}}
+  <p>This checkout session is {{.Status}}.</p>
+  {{end}}
+</body>
+</html>
+`))
+
+func (f *Fake) handleCheckout(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	sess, ok := f.sessions[r.PathValue("id")]
+	var view map[string]any
+	if ok {
+		cs := &sess.checkout
+		expireIfDue(cs)
+		view = map[string]any{
+			"ID":       cs.ID,
+			"Order":    cs.Metadata["orderID"],
+			"Amount":   fmt.Sprintf("%d.%02d", cs.AmountTotal/100, cs.AmountTotal%100),
+			"Currency": cs.Currency,
+			"Open":     cs.Status == stripe.CheckoutSessionStatusOpen,
+			"Status":   cs.Status,
+		}
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := checkoutPage.Execute(w, view); err != nil {
+		log.Printf("failed to render the fake checkout page: %v", err)
+	}
+}
+
+// handleAction completes the session the way the developer chose, reports it
+// to the webhook and sends the browser back to the shop. Failed payments
+// complete the session unpaid, like a delayed payment method that fails
+// does, and cancelled ones expire it. The session only changes once the
+// webhook took every event, so a failed action can be tried again.
+func (f *Fake) handleAction(w http.ResponseWriter, r *http.Request) {
+	var (
+		events   []stripe.EventType
+		redirect string
+	)
+
+	f.mu.Lock()
+	sess, ok := f.sessions[r.PathValue("id")]
+	if !ok {
+		f.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+
+	expireIfDue(&sess.checkout)
+	if sess.checkout.Status != stripe.CheckoutSessionStatusOpen {
+		f.mu.Unlock()
+		http.Error(w, fmt.Sprintf("the checkout session is %s", sess.checkout.Status), http.StatusConflict)
+		return
+	}
+	if sess.busy {
+		f.mu.Unlock()
+		http.Error(w, "the checkout session is being completed", http.StatusConflict)
+		return
+	}
+
+	next := sess.checkout
+	switch r.PathValue("action") {
+	case "pay":
+		next.Status = stripe.CheckoutSessionStatusComplete
+		next.PaymentStatus = stripe.CheckoutSessionPaymentStatusPaid
+		events = []stripe.EventType{stripe.EventTypeCheckoutSessionCompleted}
+		redirect = next.SuccessURL
+	case "fail":
+		next.Status = stripe.CheckoutSessionStatusComplete
+		events = []stripe.EventType{stripe.EventTypeCheckoutSessionCompleted, stripe.EventTypeCheckoutSessionAsyncPaymentFailed}
+		redirect = next.CancelURL
+	case "cancel":
+		next.Status = stripe.CheckoutSessionStatusExpired
+		events = []stripe.EventType{stripe.EventTypeCheckoutSessionExpired}
+		redirect = next.CancelURL
+	default:
+		f.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+
+	sess.busy = true
+	f.mu.Unlock()
+
+	for _, event := range events {
+		if err := f.sendWebhook(event, &next); err != nil {
+			f.mu.Lock()
+			sess.busy = false
+			f.mu.Unlock()
+
+			log.Printf("failed to send the fake %s webhook: %v", event, err)
+			http.Error(w, fmt.Sprintf("the webhook failed: %v", err), http.StatusBadGateway)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	sess.checkout = next
+	sess.busy = false
+	f.mu.Unlock()
+
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// expireIfDue expires a session whose time ran out. It is called with the
+// lock held.
+func expireIfDue(cs *stripe.CheckoutSession) {
+	if cs.Status == stripe.CheckoutSessionStatusOpen && time.Now().Unix() >= cs.ExpiresAt {
+		cs.Status = stripe.CheckoutSessionStatusExpired
+	}
+}
+
+// sendWebhook posts an event about the session to the webhook, signed with
+// the endpoint secret like Stripe does.
+func (f *Fake) sendWebhook(eventType stripe.EventType, cs *stripe.CheckoutSession) error {
+	object, err := json.Marshal(cs)
+	if err != nil {
+		return err
+	}
+
+	id, err := newID("evt_fake_")
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(&stripe.Event{
+		ID:         id,
+		Object:     "event",
+		APIVersion: stripe.APIVersion,
+		Created:    time.Now().Unix(),
+		Type:       eventType,
+		Data:       &stripe.EventData{Raw: object},
+	})
+	if err != nil {
+		return err
+	}
+
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: payload,
+		Secret:  f.webhookSecret,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, f.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Stripe-Signature", signed.Header)
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.New(res.Status)
+	}
+
+	log.Printf("Sent fake %s webhook for checkout session %s", eventType, cs.ID)
+	return nil
+}
+
+func newID(prefix string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return prefix + hex.EncodeToString(b), nil
+}
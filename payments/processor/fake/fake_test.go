@@ -0,0 +1,253 @@
+package fake
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/omsv2-payments/processor"
+	"github.com/stripe/stripe-go/v78"
+	"github.com/stripe/stripe-go/v78/webhook"
+)
+
+const testSecret = "whsec_test"
+
+// webhookRecorder is a webhook endpoint checking the signature of the events
+// the way the payments service does.
+type webhookRecorder struct {
+	t *testing.T
+
+	mu     sync.Mutex
+	events []stripe.EventType
+	// fail makes the endpoint answer with an error
+	fail bool
+}
+
+func (rec *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rec.t.Errorf("reading the webhook body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	event, err := webhook.ConstructEvent(body, r.Header.Get("Stripe-Signature"), testSecret)
+	if err != nil {
+		rec.t.Errorf("the webhook signature doesn't verify: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var cs stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Raw, &cs); err != nil || cs.Metadata["orderID"] != "order" {
+		rec.t.Errorf("the %s event holds no checkout session of the order: %v", event.Type, err)
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rec.events = append(rec.events, event.Type)
+}
+
+func (rec *webhookRecorder) setFail(fail bool) {
+	rec.mu.Lock()
+	rec.fail = fail
+	rec.mu.Unlock()
+}
+
+func (rec *webhookRecorder) received() []stripe.EventType {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return slices.Clone(rec.events)
+}
+
+// newTestFake returns a fake reporting to rec, its checkout page served by an
+// HTTP test server.
+func newTestFake(t *testing.T, rec *webhookRecorder) (*Fake, *httptest.Server) {
+	t.Helper()
+
+	hook := httptest.NewServer(rec)
+	t.Cleanup(hook.Close)
+
+	mux := http.NewServeMux()
+	checkout := httptest.NewServer(mux)
+	t.Cleanup(checkout.Close)
+
+	f := NewProcessor(checkout.URL, hook.URL, testSecret)
+	f.RegisterRoutes(mux)
+
+	// the redirects lead back to the shop, which isn't running
+	checkout.Client().CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return f, checkout
+}
+
+// paymentStatus is the status the fake reports the payment with.
+func paymentStatus(f *Fake, id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sessions[id].payment().Status
+}
+
+func order() *pb.Order {
+	return &pb.Order{ID: "order", CustomerID: "customer", Total: 1000, Currency: "usd"}
+}
+
+func TestCheckoutActions(t *testing.T) {
+	tests := []struct {
+		action     string
+		wantEvents []stripe.EventType
+		wantStatus string
+	}{
+		{
+			action:     "pay",
+			wantEvents: []stripe.EventType{stripe.EventTypeCheckoutSessionCompleted},
+			wantStatus: processor.StatusPaid,
+		},
+		{
+			action:     "fail",
+			wantEvents: []stripe.EventType{stripe.EventTypeCheckoutSessionCompleted, stripe.EventTypeCheckoutSessionAsyncPaymentFailed},
+			wantStatus: processor.StatusFailed,
+		},
+		{
+			action:     "cancel",
+			wantEvents: []stripe.EventType{stripe.EventTypeCheckoutSessionExpired},
+			wantStatus: processor.StatusExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			rec := &webhookRecorder{t: t}
+			f, checkout := newTestFake(t, rec)
+
+			p, err := f.CreatePaymentLink(order())
+			if err != nil {
+				t.Fatalf("CreatePaymentLink: %v", err)
+			}
+
+			res, err := checkout.Client().Post(p.Link+"/"+tt.action, "", nil)
+			if err != nil {
+				t.Fatalf("posting %s: %v", tt.action, err)
+			}
+			res.Body.Close()
+			if res.StatusCode != http.StatusSeeOther {
+				t.Fatalf("%s answered %s, want a redirect", tt.action, res.Status)
+			}
+
+			if got := rec.received(); !slices.Equal(got, tt.wantEvents) {
+				t.Errorf("webhook received %v, want %v", got, tt.wantEvents)
+			}
+			if got := paymentStatus(f, p.ID); got != tt.wantStatus {
+				t.Errorf("payment is %s, want %s", got, tt.wantStatus)
+			}
+
+			// the session is no longer open
+			res, err = checkout.Client().Post(p.Link+"/pay", "", nil)
+			if err != nil {
+				t.Fatalf("posting pay again: %v", err)
+			}
+			res.Body.Close()
+			if res.StatusCode != http.StatusConflict {
+				t.Errorf("paying again answered %s, want %d", res.Status, http.StatusConflict)
+			}
+		})
+	}
+}
+
+func TestCheckoutActionRetriedAfterWebhookFailure(t *testing.T) {
+	rec := &webhookRecorder{t: t, fail: true}
+	f, checkout := newTestFake(t, rec)
+
+	p, err := f.CreatePaymentLink(order())
+	if err != nil {
+		t.Fatalf("CreatePaymentLink: %v", err)
+	}
+
+	res, err := checkout.Client().Post(p.Link+"/pay", "", nil)
+	if err != nil {
+		t.Fatalf("posting pay: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadGateway {
+		t.Fatalf("pay answered %s, want %d", res.Status, http.StatusBadGateway)
+	}
+	if got := paymentStatus(f, p.ID); got != processor.StatusOpen {
+		t.Fatalf("payment is %s after the webhook failed, want %s", got, processor.StatusOpen)
+	}
+
+	rec.setFail(false)
+	res, err = checkout.Client().Post(p.Link+"/pay", "", nil)
+	if err != nil {
+		t.Fatalf("posting pay again: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusSeeOther {
+		t.Fatalf("paying again answered %s, want a redirect", res.Status)
+	}
+	if got := paymentStatus(f, p.ID); got != processor.StatusPaid {
+		t.Errorf("payment is %s, want %s", got, processor.StatusPaid)
+	}
+}
+
+func TestRefundPayment(t *testing.T) {
+	rec := &webhookRecorder{t: t}
+	f, checkout := newTestFake(t, rec)
+
+	p, err := f.CreatePaymentLink(order())
+	if err != nil {
+		t.Fatalf("CreatePaymentLink: %v", err)
+	}
+	if _, err := f.RefundPayment(p.ID, 100, "unpaid", "unpaid"); err == nil {
+		t.Fatal("refunded a payment that isn't paid")
+	}
+
+	res, err := checkout.Client().Post(p.Link+"/pay", "", nil)
+	if err != nil {
+		t.Fatalf("posting pay: %v", err)
+	}
+	res.Body.Close()
+
+	tests := []struct {
+		key          string
+		amount       int64
+		wantErr      bool
+		wantStatus   string
+		wantRefunded int64
+	}{
+		{key: "first", amount: 400, wantStatus: processor.StatusPartiallyRefunded, wantRefunded: 400},
+		// a retry of the same refund
+		{key: "first", amount: 400, wantStatus: processor.StatusPartiallyRefunded, wantRefunded: 400},
+		{key: "too much", amount: 700, wantErr: true},
+		{key: "rest", amount: 600, wantStatus: processor.StatusRefunded, wantRefunded: 1000},
+		{key: "more", amount: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		refunded, err := f.RefundPayment(p.ID, tt.amount, "test", tt.key)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("refunding %d more succeeded, want an error", tt.amount)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("refunding %d: %v", tt.amount, err)
+		}
+
+		if refunded.Status != tt.wantStatus || refunded.AmountRefunded != tt.wantRefunded {
+			t.Errorf("after refunding %d the payment is %s with %d refunded, want %s with %d",
+				tt.amount, refunded.Status, refunded.AmountRefunded, tt.wantStatus, tt.wantRefunded)
+		}
+	}
+}
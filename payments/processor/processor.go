@@ -1,7 +1,46 @@
 package processor
 
-import pb "github.com/rikughi/commons/api"
+import (
+	"errors"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+)
+
+const (
+	StatusOpen              = "open"
+	StatusProcessing        = "processing"
+	StatusPaid              = "paid"
+	StatusPartiallyRefunded = "partially_refunded"
+	StatusRefunded          = "refunded"
+	StatusExpired           = "expired"
+	StatusFailed            = "failed"
+)
+
+var ErrPaymentNotFound = errors.New("payment not found")
 
 type PaymentProcessor interface {
-	CreatePaymentLink(*pb.Order) (string, error)
+	// Name is the provider payments are recorded with.
+	Name() string
+	CreatePaymentLink(*pb.Order) (*Payment, error)
+	// ExpirePayment makes the link of a previously created payment unusable.
+	ExpirePayment(id string) error
+	// RefundPayment gives amount back to the customer of a paid payment.
+	// Calls with the same idempotency key refund once.
+	RefundPayment(id string, amount int64, reason, idempotencyKey string) (*Payment, error)
+}
+
+// Payment is a payment as the processor sees it. Its ID is the session ID
+// the processor gave it.
+type Payment struct {
+	ID             string
+	OrderID        string
+	CustomerID     string
+	Status         string
+	Link           string
+	Amount         int64
+	AmountRefunded int64
+	Currency       string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
 }
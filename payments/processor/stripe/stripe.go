@@ -1,13 +1,18 @@
 package stripe
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/omsv2-payments/processor"
 	"github.com/stripe/stripe-go/v78"
 	"github.com/stripe/stripe-go/v78/checkout/session"
+	"github.com/stripe/stripe-go/v78/refund"
 )
 
 var gatewayHTTPAddr = common.EnvString("GATEWAY_HTTP_ADDRESS", "http://localhost:8080")
@@ -18,24 +23,26 @@ func NewProcessor() *Stripe {
 	return &Stripe{}
 }
 
-func (s *Stripe) CreatePaymentLink(o *pb.Order) (string, error) {
+func (s *Stripe) Name() string {
+	return "stripe"
+}
+
+func (s *Stripe) CreatePaymentLink(o *pb.Order) (*processor.Payment, error) {
 	log.Printf("Creating payment link for order %v", o)
 
 	gatewaySuccessURL := fmt.Sprintf("%s/success.html?customerID=%s&orderID=%s", gatewayHTTPAddr, o.CustomerID, o.ID)
 	gatewayCancelURL := fmt.Sprintf("%s/cancel.html", gatewayHTTPAddr)
 
-	items := []*stripe.CheckoutSessionLineItemParams{}
-	for _, item := range o.Items {
-		items = append(items, &stripe.CheckoutSessionLineItemParams{
-			Price:    stripe.String(item.PriceID),
-			Quantity: stripe.Int64(int64(item.Quantity)),
-		})
+	items, err := lineItems(o)
+	if err != nil {
+		return nil, err
 	}
 
 	params := &stripe.CheckoutSessionParams{
 		Metadata: map[string]string{
 			"orderID":    o.ID,
 			"customerID": o.CustomerID,
+			"locationID": o.LocationID,
 		},
 		LineItems:  items,
 		Mode:       stripe.String(string(stripe.CheckoutSessionModePayment)),
@@ -45,8 +52,144 @@ func (s *Stripe) CreatePaymentLink(o *pb.Order) (string, error) {
 
 	result, err := session.New(params)
 	if err != nil {
-		return "", nil
+		return nil, err
+	}
+
+	return paymentFromSession(result), nil
+}
+
+// lineItems bills the order at the prices the orders service charged for
+// it, with the tax as a line of its own, so the session totals the order.
+func lineItems(o *pb.Order) ([]*stripe.CheckoutSessionLineItemParams, error) {
+	items := make([]*stripe.CheckoutSessionLineItemParams, 0, len(o.Items)+1)
+	line := func(name string, unitAmount, quantity int64) {
+		items = append(items, &stripe.CheckoutSessionLineItemParams{
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency:    stripe.String(o.Currency),
+				UnitAmount:  stripe.Int64(unitAmount),
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{Name: stripe.String(name)},
+			},
+			Quantity: stripe.Int64(quantity),
+		})
+	}
+
+	var total int64
+	for _, item := range o.Items {
+		if item.Currency != o.Currency {
+			return nil, fmt.Errorf("item %s is priced in %s, order %s is in %s", item.ID, item.Currency, o.ID, o.Currency)
+		}
+
+		line(item.Name, item.UnitAmount, int64(item.Quantity))
+		total += item.UnitAmount * int64(item.Quantity)
+	}
+	if o.Tax > 0 {
+		line("Tax", o.Tax, 1)
+		total += o.Tax
+	}
+
+	if total != o.Total {
+		return nil, fmt.Errorf("the items of order %s add up to %d, its total is %d", o.ID, total, o.Total)
+	}
+
+	return items, nil
+}
+
+func (s *Stripe) ExpirePayment(id string) error {
+	log.Printf("Expiring checkout session %s", id)
+
+	_, err := session.Expire(id, &stripe.CheckoutSessionExpireParams{})
+	return stripeError(err)
+}
+
+// getPayment gets the checkout session along with the charge it made, which
+// tells how much was refunded.
+func (s *Stripe) getPayment(id string) (*processor.Payment, error) {
+	params := &stripe.CheckoutSessionParams{}
+	params.AddExpand("payment_intent.latest_charge")
+
+	result, err := session.Get(id, params)
+	if err != nil {
+		return nil, stripeError(err)
+	}
+
+	return paymentFromSession(result), nil
+}
+
+// RefundPayment refunds the payment intent the checkout session paid with.
+// Stripe only accepts a few refund reasons, ours is kept in the metadata.
+func (s *Stripe) RefundPayment(id string, amount int64, reason, idempotencyKey string) (*processor.Payment, error) {
+	params := &stripe.CheckoutSessionParams{}
+	params.AddExpand("payment_intent")
+
+	result, err := session.Get(id, params)
+	if err != nil {
+		return nil, stripeError(err)
+	}
+	if result.PaymentIntent == nil {
+		return nil, fmt.Errorf("checkout session %s has no payment to refund", id)
+	}
+
+	log.Printf("Refunding %d of checkout session %s for order %s", amount, id, result.Metadata["orderID"])
+
+	refundParams := &stripe.RefundParams{
+		PaymentIntent: stripe.String(result.PaymentIntent.ID),
+		Amount:        stripe.Int64(amount),
+		Metadata:      map[string]string{"reason": reason},
+	}
+	refundParams.SetIdempotencyKey(idempotencyKey)
+
+	_, err = refund.New(refundParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPayment(id)
+}
+
+func paymentFromSession(cs *stripe.CheckoutSession) *processor.Payment {
+	p := &processor.Payment{
+		ID:         cs.ID,
+		OrderID:    cs.Metadata["orderID"],
+		CustomerID: cs.Metadata["customerID"],
+		Link:       cs.URL,
+		Amount:     cs.AmountTotal,
+		Currency:   string(cs.Currency),
+		CreatedAt:  time.Unix(cs.Created, 0),
+		ExpiresAt:  time.Unix(cs.ExpiresAt, 0),
+	}
+	if cs.PaymentIntent != nil && cs.PaymentIntent.LatestCharge != nil {
+		p.AmountRefunded = cs.PaymentIntent.LatestCharge.AmountRefunded
+	}
+
+	switch {
+	case cs.Status == stripe.CheckoutSessionStatusOpen:
+		p.Status = processor.StatusOpen
+	case cs.Status == stripe.CheckoutSessionStatusExpired:
+		p.Status = processor.StatusExpired
+	case cs.PaymentStatus == stripe.CheckoutSessionPaymentStatusUnpaid:
+		// completed with a payment method that settles later
+		p.Status = processor.StatusProcessing
+	case p.AmountRefunded >= p.Amount && p.Amount > 0:
+		p.Status = processor.StatusRefunded
+	case p.AmountRefunded > 0:
+		p.Status = processor.StatusPartiallyRefunded
+	default:
+		p.Status = processor.StatusPaid
+	}
+
+	return p
+}
+
+// stripeError tells missing checkout sessions apart.
+func stripeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var stripeErr *stripe.Error
+	if errors.As(err, &stripeErr) && stripeErr.HTTPStatusCode == http.StatusNotFound {
+		return processor.ErrPaymentNotFound
 	}
 
-	return result.URL, nil
+	return err
 }
@@ -2,32 +2,322 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	pb "github.com/rikughi/commons/api"
 	"github.com/rikughi/omsv2-payments/gateway"
 	"github.com/rikughi/omsv2-payments/processor"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type service struct {
 	processor processor.PaymentProcessor
 	gateway   gateway.OrdersGateway
+	store     PaymentsStore
 }
 
-func NewService(processor processor.PaymentProcessor, gateway gateway.OrdersGateway) *service {
-	return &service{processor, gateway}
+func NewService(processor processor.PaymentProcessor, gateway gateway.OrdersGateway, store PaymentsStore) *service {
+	return &service{processor, gateway, store}
 }
 
-func (s *service) CreatePayment(ctx context.Context, o *pb.Order) (string, error) {
-	link, err := s.processor.CreatePaymentLink(o)
+// CreatePayment hands the link of an open payment to the order, starting a
+// payment unless the order already has an open one, which happens when
+// order.created is delivered again.
+func (s *service) CreatePayment(ctx context.Context, o *pb.Order) (*Payment, error) {
+	payment, err := s.openPayment(ctx, o.ID)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if payment == nil {
+		if payment, err = s.startPayment(ctx, o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.PaymentLink == payment.Link {
+		return payment, nil
 	}
 
 	// update order with the link
-	err = s.gateway.UpdateOrderAfterPaymentLink(ctx, o.ID, link)
+	err = s.gateway.UpdateOrderAfterPaymentLink(ctx, o.ID, o.CustomerID, payment.Link)
+	if status.Code(err) == codes.FailedPrecondition {
+		// the order was cancelled while the link was being created
+		if err := s.expire(ctx, payment, "order closed"); err != nil {
+			log.Printf("failed to expire payment link for order %s: %v", o.ID, err)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// openPayment finds the payment of an order the customer can still pay
+// through, nil when there is none. Payments whose link expired without the
+// processor telling yet are recorded as expired.
+func (s *service) openPayment(ctx context.Context, orderID string) (*Payment, error) {
+	payments, err := s.store.ListForOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, p := range payments {
+		if p.Status != processor.StatusOpen {
+			continue
+		}
+		if p.open(now) {
+			return p, nil
+		}
+
+		err := s.setStatus(ctx, p, processor.StatusExpired, p.AmountRefunded, "link expired")
+		if err != nil && !errors.Is(err, ErrPaymentNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// startPayment creates a payment link for the order and records it.
+func (s *service) startPayment(ctx context.Context, o *pb.Order) (*Payment, error) {
+	created, err := s.processor.CreatePaymentLink(o)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payment := &Payment{
+		ID:                primitive.NewObjectID(),
+		OrderID:           o.ID,
+		CustomerID:        o.CustomerID,
+		Provider:          s.processor.Name(),
+		ProviderSessionID: created.ID,
+		Status:            processor.StatusOpen,
+		Link:              created.Link,
+		Amount:            created.Amount,
+		Currency:          created.Currency,
+		History:           []StatusChange{{To: processor.StatusOpen, Reason: "payment link created", At: now}},
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		ExpiresAt:         created.ExpiresAt,
+	}
+
+	err = s.store.Create(ctx, payment)
+	if errors.Is(err, ErrDuplicatePayment) {
+		// another attempt for the order got there first, keep that one
+		if err := s.processor.ExpirePayment(created.ID); err != nil {
+			log.Printf("failed to expire duplicate payment link for order %s: %v", o.ID, err)
+		}
+
+		payment, err := s.openPayment(ctx, o.ID)
+		if err == nil && payment == nil {
+			err = status.Errorf(codes.Aborted, "the payments of order %s changed, try again", o.ID)
+		}
+		return payment, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// CancelPayment expires the open payments of an order so the customer can
+// no longer pay for it, and refunds what the customer already paid for an
+// order cancelled before the kitchen started on it. A session that is
+// already completed or expired can't be expired again, so those failures
+// are only logged. Failed refunds are returned to be tried again.
+func (s *service) CancelPayment(ctx context.Context, o *pb.Order) error {
+	payments, err := s.store.ListForOrder(ctx, o.ID)
+	if err != nil {
+		return err
+	}
+
+	reason := "order " + o.Status
+
+	var errs []error
+	for _, p := range payments {
+		switch {
+		case p.Status == processor.StatusOpen:
+			if err := s.expire(ctx, p, reason); err != nil {
+				log.Printf("failed to expire payment %s of order %s: %v", p.ID.Hex(), o.ID, err)
+			}
+		case p.refundable() > 0:
+			if _, err := s.RefundPayment(ctx, p.ID.Hex(), 0, reason); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// expire makes the link of a payment unusable. A payment the processor
+// refuses to expire, because it was just paid, keeps its status.
+func (s *service) expire(ctx context.Context, p *Payment, reason string) error {
+	if err := s.processor.ExpirePayment(p.ProviderSessionID); err != nil {
+		return err
+	}
+
+	return s.setStatus(ctx, p, processor.StatusExpired, p.AmountRefunded, reason)
+}
+
+// setStatus records a change of the status of p and applies it to p.
+func (s *service) setStatus(ctx context.Context, p *Payment, to string, amountRefunded int64, reason string) error {
+	change := StatusChange{From: p.Status, To: to, Reason: reason, At: time.Now()}
+	if err := s.store.UpdateStatus(ctx, p.ID, change, amountRefunded); err != nil {
+		return err
+	}
+
+	p.Status = to
+	p.AmountRefunded = amountRefunded
+	p.UpdatedAt = change.At
+	p.History = append(p.History, change)
+	return nil
+}
+
+func (s *service) GetPayment(ctx context.Context, id string) (*Payment, error) {
+	oID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "payment %s not found", id)
+	}
+
+	payment, err := s.store.Get(ctx, oID)
+	if errors.Is(err, ErrPaymentNotFound) {
+		return nil, status.Errorf(codes.NotFound, "payment %s not found", id)
+	}
+
+	return payment, err
+}
+
+// CreatePaymentLink hands out the link of the open payment of an order
+// awaiting payment. Links expire after a while, a new payment is created for
+// the order once its link did.
+func (s *service) CreatePaymentLink(ctx context.Context, orderID, customerID string) (*Payment, error) {
+	o, err := s.gateway.GetOrder(ctx, orderID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the statuses of the orders service an order can be paid in
+	if o.Status != "pending" && o.Status != "waiting_payment" {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is %s", o.ID, o.Status)
+	}
+
+	payments, err := s.store.ListForOrder(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payments {
+		// the customer may try again after a failed payment
+		if p.Status != processor.StatusOpen && p.Status != processor.StatusExpired && p.Status != processor.StatusFailed {
+			return nil, status.Errorf(codes.FailedPrecondition, "order %s was already paid", o.ID)
+		}
+	}
+
+	return s.CreatePayment(ctx, o)
+}
+
+// ListPaymentsForOrder lists every payment attempt of an order, oldest first.
+func (s *service) ListPaymentsForOrder(ctx context.Context, orderID, customerID string) ([]*Payment, error) {
+	// the order must belong to the customer
+	if _, err := s.gateway.GetOrder(ctx, orderID, customerID); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListForOrder(ctx, orderID)
+}
+
+// RefundPayment refunds amount of a paid payment, whatever is left to refund
+// when amount is 0. One refund of a payment runs at a time, the others fail
+// with Aborted.
+func (s *service) RefundPayment(ctx context.Context, id string, amount int64, reason string) (*Payment, error) {
+	if amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "the amount to refund can't be negative")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "a reason is required")
+	}
+
+	payment, err := s.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status == StatusRefunding {
+		return nil, status.Errorf(codes.Aborted, "payment %s is being refunded, try again", id)
+	}
+
+	refundable := payment.refundable()
+	if refundable == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment %s is %s, there is nothing to refund", id, payment.Status)
+	}
+	if amount == 0 {
+		amount = refundable
+	}
+	if amount > refundable {
+		return nil, status.Errorf(codes.FailedPrecondition, "only %d of payment %s is left to refund", refundable, id)
+	}
+
+	// claim the payment so a concurrent refund can't refund it as well
+	previous := payment.Status
+	err = s.setStatus(ctx, payment, StatusRefunding, payment.AmountRefunded, reason)
+	if errors.Is(err, ErrPaymentNotFound) {
+		return nil, status.Errorf(codes.Aborted, "payment %s changed while it was refunded, try again", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the refunds of a payment are told apart by the amount refunded before
+	// them, so the processor makes a refund that is tried again only once
+	key := fmt.Sprintf("refund-%s-%d", payment.ID.Hex(), payment.AmountRefunded)
+
+	refunded, err := s.processor.RefundPayment(payment.ProviderSessionID, amount, reason, key)
+	if err != nil {
+		if err := s.setStatus(ctx, payment, previous, payment.AmountRefunded, "refund failed"); err != nil {
+			log.Printf("failed to give payment %s back its %s status: %v", id, previous, err)
+		}
+		return nil, err
+	}
+
+	if err := s.setStatus(ctx, payment, refunded.Status, refunded.AmountRefunded, reason); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (s *service) RecordPaymentStatus(ctx context.Context, sessionID, to, reason string) (*Payment, bool, error) {
+	payment, err := s.store.GetBySession(ctx, s.processor.Name(), sessionID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// late or redelivered webhooks don't take back a payment that went
+	// through
+	if payment.Status == to || payment.settled() {
+		return payment, false, nil
+	}
+
+	err = s.setStatus(ctx, payment, to, payment.AmountRefunded, reason)
+	if errors.Is(err, ErrPaymentNotFound) {
+		// another delivery of the webhook changed it first
+		payment, err = s.store.Get(ctx, payment.ID)
+		return payment, false, err
+	}
 	if err != nil {
-		return "", err
+		return nil, false, err
 	}
 
-	return link, nil
+	return payment, true, nil
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rikughi/omsv2-payments/processor"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DbName   = "payments"
+	CollName = "payments"
+)
+
+type store struct {
+	db *mongo.Client
+}
+
+func NewStore(db *mongo.Client) *store {
+	return &store{db}
+}
+
+func (s *store) Create(ctx context.Context, p *Payment) error {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	if p.ID.IsZero() {
+		p.ID = primitive.NewObjectID()
+	}
+
+	_, err := col.InsertOne(ctx, p)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicatePayment
+	}
+
+	return err
+}
+
+func (s *store) Get(ctx context.Context, id primitive.ObjectID) (*Payment, error) {
+	return s.findOne(ctx, bson.M{"_id": id})
+}
+
+func (s *store) GetBySession(ctx context.Context, provider, sessionID string) (*Payment, error) {
+	return s.findOne(ctx, bson.M{"provider": provider, "providerSessionID": sessionID})
+}
+
+func (s *store) findOne(ctx context.Context, filter bson.M) (*Payment, error) {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	var p Payment
+	err := col.FindOne(ctx, filter).Decode(&p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (s *store) ListForOrder(ctx context.Context, orderID string) ([]*Payment, error) {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := col.Find(ctx, bson.M{"orderID": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make([]*Payment, 0)
+	if err := cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (s *store) UpdateStatus(ctx context.Context, id primitive.ObjectID, change StatusChange, amountRefunded int64) error {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	res, err := col.UpdateOne(ctx,
+		bson.M{"_id": id, "status": change.From},
+		bson.M{
+			"$set": bson.M{
+				"status":         change.To,
+				"amountRefunded": amountRefunded,
+				"updatedAt":      change.At,
+			},
+			"$push": bson.M{"history": change},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// EnsureIndexes creates the indexes backing the lookups of the payments of
+// an order and of the sessions webhooks report about. An order has one open
+// payment at most.
+func (s *store) EnsureIndexes(ctx context.Context) error {
+	col := s.db.Database(DbName).Collection(CollName)
+
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "orderID", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "providerSessionID", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "orderID", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": processor.StatusOpen}),
+		},
+	})
+
+	return err
+}
@@ -2,10 +2,128 @@ package main
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/omsv2-payments/processor"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrDuplicatePayment is returned when the order already has an open
+	// payment
+	ErrDuplicatePayment = errors.New("the order already has an open payment")
+)
+
+// StatusRefunding is the status of a payment while the processor refunds
+// it. A refund claims the payment with it so no other refund starts
+// meanwhile.
+const StatusRefunding = "refunding"
+
 type PaymentsService interface {
-	CreatePayment(context.Context, *pb.Order) (string, error)
+	CreatePayment(context.Context, *pb.Order) (*Payment, error)
+	CancelPayment(context.Context, *pb.Order) error
+	GetPayment(ctx context.Context, id string) (*Payment, error)
+	// CreatePaymentLink returns the open payment of an order, creating a new
+	// one when the previous link expired.
+	CreatePaymentLink(ctx context.Context, orderID, customerID string) (*Payment, error)
+	ListPaymentsForOrder(ctx context.Context, orderID, customerID string) ([]*Payment, error)
+	RefundPayment(ctx context.Context, id string, amount int64, reason string) (*Payment, error)
+	// RecordPaymentStatus applies a status the processor reported for one of
+	// its sessions and returns the payment as it is afterwards. It tells
+	// whether the payment changed, which it doesn't for redelivered webhooks.
+	RecordPaymentStatus(ctx context.Context, sessionID, status, reason string) (*Payment, bool, error)
+}
+
+type PaymentsStore interface {
+	// Create fails with ErrDuplicatePayment when the payment is open and the
+	// order already has an open payment.
+	Create(ctx context.Context, p *Payment) error
+	Get(ctx context.Context, id primitive.ObjectID) (*Payment, error)
+	GetBySession(ctx context.Context, provider, sessionID string) (*Payment, error)
+	// ListForOrder lists the payments of an order, oldest first.
+	ListForOrder(ctx context.Context, orderID string) ([]*Payment, error)
+	// UpdateStatus records a change of the status of a payment and sets the
+	// amount refunded. It fails with ErrPaymentNotFound when the payment is
+	// no longer in the status the change is from.
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, change StatusChange, amountRefunded int64) error
+}
+
+// Payment is an attempt to pay for an order, a checkout session of the
+// payment provider. An order gets a new payment each time its link expires.
+type Payment struct {
+	ID                primitive.ObjectID `bson:"_id"`
+	OrderID           string             `bson:"orderID"`
+	CustomerID        string             `bson:"customerID"`
+	Provider          string             `bson:"provider"`
+	ProviderSessionID string             `bson:"providerSessionID"`
+	Status            string             `bson:"status"`
+	Link              string             `bson:"link"`
+	// amounts are in the currency's minor unit, e.g. cents
+	Amount         int64          `bson:"amount"`
+	AmountRefunded int64          `bson:"amountRefunded"`
+	Currency       string         `bson:"currency"`
+	History        []StatusChange `bson:"history"`
+	CreatedAt      time.Time      `bson:"createdAt"`
+	UpdatedAt      time.Time      `bson:"updatedAt"`
+	ExpiresAt      time.Time      `bson:"expiresAt"`
+}
+
+type StatusChange struct {
+	From   string    `bson:"from"`
+	To     string    `bson:"to"`
+	Reason string    `bson:"reason,omitempty"`
+	At     time.Time `bson:"at"`
+}
+
+// open tells whether the customer can still pay through the link at t. The
+// provider may not have reported the expiry of the link yet.
+func (p *Payment) open(t time.Time) bool {
+	return p.Status == processor.StatusOpen && t.Before(p.ExpiresAt)
+}
+
+// settled tells whether the payment went through. Only refunds change it
+// from then on.
+func (p *Payment) settled() bool {
+	switch p.Status {
+	case processor.StatusPaid, StatusRefunding, processor.StatusPartiallyRefunded, processor.StatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// refundable tells how much of the payment can still be refunded.
+func (p *Payment) refundable() int64 {
+	if p.Status != processor.StatusPaid && p.Status != processor.StatusPartiallyRefunded {
+		return 0
+	}
+
+	return p.Amount - p.AmountRefunded
+}
+
+func (p *Payment) ToProto() *pb.Payment {
+	history := make([]*pb.PaymentStatusChange, 0, len(p.History))
+	for _, c := range p.History {
+		history = append(history, &pb.PaymentStatusChange{From: c.From, To: c.To, Reason: c.Reason, Timestamp: c.At.Unix()})
+	}
+
+	return &pb.Payment{
+		ID:                p.ID.Hex(),
+		OrderID:           p.OrderID,
+		CustomerID:        p.CustomerID,
+		Status:            p.Status,
+		Link:              p.Link,
+		Amount:            p.Amount,
+		AmountRefunded:    p.AmountRefunded,
+		Currency:          p.Currency,
+		CreatedAt:         p.CreatedAt.Unix(),
+		ExpiresAt:         p.ExpiresAt.Unix(),
+		Provider:          p.Provider,
+		ProviderSessionID: p.ProviderSessionID,
+		UpdatedAt:         p.UpdatedAt.Unix(),
+		History:           history,
+	}
 }
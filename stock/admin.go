@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+
+	maxItemIDLength   = 64
+	maxItemNameLength = 200
+)
+
+var (
+	itemIDPattern   = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	currencyPattern = regexp.MustCompile(`^[a-z]{3}$`)
+)
+
+// validateItem checks the catalog details of an item. Currencies are ISO
+// 4217 codes, stored in lower case like the payment provider expects them.
+func validateItem(item *Item) error {
+	if len(item.ID) > maxItemIDLength || !itemIDPattern.MatchString(item.ID) {
+		return status.Errorf(codes.InvalidArgument, "invalid item ID %q, use up to %d letters, digits, - or _", item.ID, maxItemIDLength)
+	}
+
+	item.Name = strings.TrimSpace(item.Name)
+	if item.Name == "" {
+		return status.Error(codes.InvalidArgument, "item name is required")
+	}
+	if len(item.Name) > maxItemNameLength {
+		return status.Errorf(codes.InvalidArgument, "item name is longer than %d characters", maxItemNameLength)
+	}
+
+	if item.UnitAmount <= 0 {
+		return status.Error(codes.InvalidArgument, "unit amount must be positive")
+	}
+
+	item.Currency = strings.ToLower(item.Currency)
+	if !currencyPattern.MatchString(item.Currency) {
+		return status.Errorf(codes.InvalidArgument, "invalid currency %q", item.Currency)
+	}
+
+	if item.LowStockThreshold < 0 {
+		return status.Error(codes.InvalidArgument, "low stock threshold can't be negative")
+	}
+	if item.ReorderPoint < 0 {
+		return status.Error(codes.InvalidArgument, "reorder point can't be negative")
+	}
+
+	for location, level := range item.Levels {
+		if _, err := validateLocation(location); err != nil {
+			return err
+		}
+		if level.Quantity < 0 {
+			return status.Error(codes.InvalidArgument, "quantity can't be negative")
+		}
+	}
+
+	if err := validateSchedule(item.Schedule); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(item.Recipe))
+	for _, ingredient := range item.Recipe {
+		switch {
+		case ingredient.ItemID == "":
+			return status.Error(codes.InvalidArgument, "ingredient item ID is required")
+		case ingredient.ItemID == item.ID:
+			return status.Errorf(codes.InvalidArgument, "item %s can't be its own ingredient", item.ID)
+		case seen[ingredient.ItemID]:
+			return status.Errorf(codes.InvalidArgument, "ingredient %s is listed twice", ingredient.ItemID)
+		case ingredient.Quantity <= 0:
+			return status.Errorf(codes.InvalidArgument, "invalid quantity %d for ingredient %s", ingredient.Quantity, ingredient.ItemID)
+		}
+		seen[ingredient.ItemID] = true
+	}
+
+	return nil
+}
+
+// validateLot checks the lot a restock goes into and returns when it
+// expires, nil when it doesn't. Lot IDs follow the rules of item IDs.
+func validateLot(p *pb.AdjustQuantityRequest) (*time.Time, error) {
+	if p.LotID == "" && p.ExpiresAt == 0 {
+		return nil, nil
+	}
+	if !p.Restock {
+		return nil, status.Error(codes.InvalidArgument, "only restocks go into a lot")
+	}
+	if p.LotID != "" && (len(p.LotID) > maxItemIDLength || !itemIDPattern.MatchString(p.LotID)) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid lot ID %q, use up to %d letters, digits, - or _", p.LotID, maxItemIDLength)
+	}
+	if p.ExpiresAt == 0 {
+		return nil, nil
+	}
+
+	expiresAt := time.Unix(p.ExpiresAt, 0).UTC()
+	if !expiresAt.After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "the lot already expired")
+	}
+
+	return &expiresAt, nil
+}
+
+// validateLocation checks a location ID, an empty one stands for the default
+// location. Location IDs follow the rules of item IDs, which also keeps them
+// usable in store field paths.
+func validateLocation(location string) (string, error) {
+	location = common.LocationOrDefault(location)
+	if len(location) > maxItemIDLength || !itemIDPattern.MatchString(location) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid location ID %q, use up to %d letters, digits, - or _", location, maxItemIDLength)
+	}
+
+	return location, nil
+}
+
+func (s *Service) CreateItem(ctx context.Context, item *Item) (*Item, error) {
+	if item.ID == "" {
+		item.ID = primitive.NewObjectID().Hex()
+	}
+	if err := validateItem(item); err != nil {
+		return nil, err
+	}
+
+	err := s.createItem(ctx, item, "")
+	if errors.Is(err, ErrItemExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "item %s already exists", item.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Seed creates the given items unless they already exist. Their quantities
+// are recorded as the initial restock of each location.
+func (s *Service) Seed(ctx context.Context, items []*Item) error {
+	for _, item := range items {
+		err := s.createItem(ctx, item, "initial stock")
+		if err != nil && !errors.Is(err, ErrItemExists) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createItem stores an item with no stock and records its quantity at each
+// location as a restock, so the ledger accounts for all of it.
+func (s *Service) createItem(ctx context.Context, item *Item, reason string) error {
+	initial := item.Levels
+
+	return s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.validateRecipe(ctx, item); err != nil {
+			return err
+		}
+
+		empty := *item
+		empty.Levels = nil
+		if err := s.store.CreateItem(ctx, &empty); err != nil {
+			return err
+		}
+
+		item.Levels = make(map[string]*StockLevel, len(initial))
+		for _, location := range sortedLocations(initial) {
+			quantity := initial[location].Quantity
+			if quantity == 0 {
+				continue
+			}
+
+			e := newMovement(ctx, item.ID, location, MovementRestock, quantity, 0, reason)
+			if err := s.applyMovement(ctx, e); err != nil {
+				return err
+			}
+			item.Levels[location] = &StockLevel{Quantity: e.Quantity}
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) UpdateItem(ctx context.Context, item *Item) (*Item, error) {
+	if err := validateItem(item); err != nil {
+		return nil, err
+	}
+
+	var updated *Item
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.store.GetItem(ctx, item.ID)
+		if err != nil {
+			return stockError(item.ID, err)
+		}
+
+		// quantities only change through movements
+		item.Levels = current.Levels
+		if err := s.validateRecipe(ctx, item); err != nil {
+			return err
+		}
+
+		if err := s.store.UpdateItem(ctx, item); err != nil {
+			return stockError(item.ID, err)
+		}
+
+		updated, err = s.store.GetItem(ctx, item.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteItem removes an item from the catalog. Items held for pending orders
+// can't be deleted until the orders are settled.
+func (s *Service) DeleteItem(ctx context.Context, id string) (*Item, error) {
+	var item *Item
+
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		item, err = s.store.GetItem(ctx, id)
+		if err != nil {
+			return stockError(id, err)
+		}
+
+		locations := item.locations()
+		for _, location := range locations {
+			if reserved := item.Level(location).Reserved; reserved > 0 {
+				return status.Errorf(codes.FailedPrecondition, "item %s has %d units reserved for pending orders at %s", id, reserved, location)
+			}
+		}
+
+		usedIn, err := s.store.ListItems(ctx, ListItemsFilter{Ingredient: id, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(usedIn) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "item %s is an ingredient of %s", id, usedIn[0].ID)
+		}
+
+		// write off what is left so the ledger balances to zero
+		for _, location := range locations {
+			quantity := item.Level(location).Quantity
+			if quantity == 0 {
+				continue
+			}
+
+			e := newMovement(ctx, id, location, MovementAdjustment, -quantity, 0, "item deleted")
+			if err := s.applyMovement(ctx, e); err != nil {
+				return stockError(id, err)
+			}
+		}
+
+		return stockError(id, s.store.DeleteItem(ctx, id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ListItems pages through the catalog. With ExcludeDepleted it only lists
+// the items the location has stock for, with AvailableNow the items whose
+// schedule allows ordering them now and with SellableOnly the items that
+// aren't only ingredients.
+func (s *Service) ListItems(ctx context.Context, p *pb.ListItemsRequest) ([]*Item, string, error) {
+	location, err := validateLocation(p.LocationID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageSize := int(p.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var after string
+	if p.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(p.PageToken)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		after = string(b)
+	}
+
+	// fetch one extra item to know whether there is a next page
+	items, err := s.store.ListItems(ctx, ListItemsFilter{
+		After:           after,
+		Limit:           int64(pageSize + 1),
+		ExcludeDepleted: p.ExcludeDepleted,
+		Location:        location,
+		SellableOnly:    p.SellableOnly,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		next = base64.RawURLEncoding.EncodeToString([]byte(items[pageSize-1].ID))
+	}
+
+	if p.ExcludeDepleted {
+		items, err = s.excludeUnmakeable(ctx, location, items)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if p.AvailableNow {
+		now := time.Now()
+		items = slices.DeleteFunc(items, func(item *Item) bool { return !item.Schedule.OpenAt(now) })
+	}
+
+	return items, next, nil
+}
+
+// excludeUnmakeable leaves out the items made from ingredients that ran out
+// at the location. The store only knows about the stock items hold
+// themselves, so pages may come out shorter than asked for.
+func (s *Service) excludeUnmakeable(ctx context.Context, location string, items []*Item) ([]*Item, error) {
+	c := newCatalog(location, items)
+	if err := s.addIngredients(ctx, c, items); err != nil {
+		return nil, err
+	}
+
+	kept := make([]*Item, 0, len(items))
+	for _, item := range items {
+		if c.makeable(item, onHand) > 0 {
+			kept = append(kept, item)
+		}
+	}
+
+	return kept, nil
+}
+
+// AdjustQuantity corrects the quantity on hand at a location after a stock
+// count, or adds a delivery when restock is set. Deliveries are received
+// into a lot, which may expire. It never takes away stock that is reserved.
+func (s *Service) AdjustQuantity(ctx context.Context, p *pb.AdjustQuantityRequest) (*Item, error) {
+	item, e, err := s.adjustQuantity(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.alerts != nil {
+		s.alerts.Notify(ctx, []*Item{item}, []*LedgerEntry{e})
+	}
+	return item, nil
+}
+
+// adjustQuantity applies an adjustment without sending the alerts it
+// triggers, which is up to the caller once the adjustment is committed.
+func (s *Service) adjustQuantity(ctx context.Context, p *pb.AdjustQuantityRequest) (*Item, *LedgerEntry, error) {
+	id, delta, reason, restock := p.ItemID, p.Delta, p.Reason, p.Restock
+
+	location, err := validateLocation(p.LocationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if delta == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "delta can't be zero")
+	}
+	if restock && delta < 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "a restock can't remove stock")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "a reason is required")
+	}
+
+	expiresAt, err := validateLot(p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kind := MovementAdjustment
+	if restock {
+		kind = MovementRestock
+	}
+
+	var (
+		item *Item
+		e    *LedgerEntry
+	)
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.store.GetItem(ctx, id)
+		if err != nil {
+			return stockError(id, err)
+		}
+		if len(current.Recipe) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "item %s is made from ingredients, adjust those instead", id)
+		}
+
+		e = newMovement(ctx, id, location, kind, delta, 0, reason)
+		if restock {
+			lot, err := receiveLot(current.Level(location), p.LotID, delta, expiresAt)
+			if err != nil {
+				return err
+			}
+			e.Lots = []LotMovement{lot}
+		}
+
+		err = s.applyMovement(ctx, e)
+		if errors.Is(err, ErrInsufficientStock) {
+			return status.Errorf(codes.FailedPrecondition, "adjusting item %s by %d would leave less than is reserved", id, delta)
+		}
+		if err != nil {
+			return stockError(id, err)
+		}
+
+		item, err = s.store.GetItem(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return item, e, nil
+}
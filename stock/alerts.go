@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/commons/broker"
+	"go.opentelemetry.io/otel"
+)
+
+// stockAlerts publishes stock.low and stock.depleted when the quantity on
+// hand of an item at a location drops to its threshold or to zero. An item
+// only alerts again for a location once the debounce window passed, so stock
+// moving back and forth around a threshold doesn't flood the exchange.
+type stockAlerts struct {
+	channel  *amqp.Channel
+	debounce time.Duration
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+func NewStockAlerts(channel *amqp.Channel, debounce time.Duration) *stockAlerts {
+	return &stockAlerts{
+		channel:  channel,
+		debounce: debounce,
+		sent:     make(map[string]time.Time),
+	}
+}
+
+// Notify checks the movements applied to the given items, as they are after
+// the movements, and publishes the thresholds they crossed.
+func (a *stockAlerts) Notify(ctx context.Context, items []*Item, entries []*LedgerEntry) {
+	byID := make(map[string]*Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	for _, e := range entries {
+		item, ok := byID[e.ItemID]
+		if !ok {
+			continue
+		}
+
+		if event := crossedThreshold(item, e); event != "" {
+			a.publish(ctx, event, item, e)
+		}
+	}
+}
+
+// crossedThreshold tells which event, if any, a movement triggers: the
+// quantity before it was above the level, the quantity after it is not.
+func crossedThreshold(item *Item, e *LedgerEntry) string {
+	before := e.Quantity - e.QuantityDelta
+	after := e.Quantity
+
+	switch {
+	case before > 0 && after <= 0:
+		return broker.StockDepletedEvent
+	case item.LowStockThreshold > 0 && before > item.LowStockThreshold && after <= item.LowStockThreshold:
+		return broker.StockLowEvent
+	default:
+		return ""
+	}
+}
+
+func (a *stockAlerts) publish(ctx context.Context, event string, item *Item, e *LedgerEntry) {
+	key := event + ":" + item.ID + ":" + e.LocationID
+
+	a.mu.Lock()
+	last, ok := a.sent[key]
+	if ok && time.Since(last) < a.debounce {
+		a.mu.Unlock()
+		return
+	}
+	a.sent[key] = time.Now()
+	a.mu.Unlock()
+
+	body, err := json.Marshal(&pb.StockAlert{
+		ItemID:            item.ID,
+		Name:              item.Name,
+		Quantity:          e.Quantity,
+		LowStockThreshold: item.LowStockThreshold,
+		Timestamp:         time.Now().Unix(),
+		LocationID:        e.LocationID,
+	})
+	if err != nil {
+		log.Printf("failed to marshal %s alert: %v", event, err)
+		return
+	}
+
+	if err := a.send(ctx, event, body); err != nil {
+		log.Printf("failed to publish %s for item %s at %s: %v", event, item.ID, e.LocationID, err)
+
+		// let the next movement try again
+		a.mu.Lock()
+		delete(a.sent, key)
+		a.mu.Unlock()
+		return
+	}
+
+	log.Printf("published %s for item %s at %s", event, item.ID, e.LocationID)
+}
+
+// PublishExpiring publishes stock.expiring with the lots expiring by until.
+func (a *stockAlerts) PublishExpiring(ctx context.Context, until time.Time, lots []*ExpiringLot) error {
+	now := time.Now()
+	p := &pb.ExpiringLots{
+		Until:     until.Unix(),
+		Timestamp: now.Unix(),
+		Lots:      make([]*pb.ExpiringLot, 0, len(lots)),
+	}
+	for _, l := range lots {
+		p.Lots = append(p.Lots, &pb.ExpiringLot{
+			ItemID:     l.Item.ID,
+			Name:       l.Item.Name,
+			LocationID: l.LocationID,
+			Lot:        l.Lot.ToProto(now),
+		})
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return a.send(ctx, broker.StockExpiringEvent, body)
+}
+
+func (a *stockAlerts) send(ctx context.Context, event string, body []byte) error {
+	tr := otel.Tracer("amqp")
+	amqpContext, messageSpan := tr.Start(ctx, fmt.Sprintf("AMQP - publish - %s", event))
+	defer messageSpan.End()
+
+	return a.channel.PublishWithContext(amqpContext, event, "", false, false, amqp.Publishing{
+		AppId:        "stock",
+		ContentType:  "application/json",
+		Body:         body,
+		DeliveryMode: amqp.Persistent,
+		Headers:      broker.InjectAMQPHeaders(amqpContext),
+	})
+}
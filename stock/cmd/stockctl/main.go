@@ -0,0 +1,274 @@
+// Command stockctl imports and exports the stock catalog as CSV or JSON
+// files:
+//
+//	stockctl import [-dry-run] [-location ID] [-format csv|json] FILE
+//	stockctl export [-location ID] [-format csv|json] [-o FILE]
+//
+// It finds the stock service through consul unless -addr is given.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/commons/discovery"
+	"github.com/rikughi/commons/discovery/consul"
+	"github.com/rikughi/omsv2-stock/itemfile"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const chunkSize = 32 << 10
+
+var consulAddr = common.EnvString("CONSUL_ADDR", "localhost:8500")
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: stockctl import [flags] FILE")
+	fmt.Fprintln(os.Stderr, "       stockctl export [flags]")
+	os.Exit(2)
+}
+
+type connFlags struct {
+	addr     string
+	location string
+	format   string
+	timeout  time.Duration
+}
+
+func (c *connFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.addr, "addr", "", "stock service address, discovered through consul when empty")
+	fs.StringVar(&c.location, "location", "", "location of the quantities, the default location when empty")
+	fs.StringVar(&c.format, "format", "", "csv or json, guessed from the file name when empty")
+	fs.DurationVar(&c.timeout, "timeout", time.Minute, "time allowed for the whole transfer")
+}
+
+func (c *connFlags) connect(ctx context.Context) (pb.StockServiceClient, func(), error) {
+	var (
+		conn *grpc.ClientConn
+		err  error
+	)
+	if c.addr != "" {
+		conn, err = grpc.NewClient(c.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		var registry *consul.Registry
+		registry, err = consul.NewRegistery(consulAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+		conn, err = discovery.ServiceConnection(ctx, "stock", registry)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to the stock service: %w", err)
+	}
+
+	return pb.NewStockServiceClient(conn), func() { conn.Close() }, nil
+}
+
+// formatOf picks the format of a file, from its extension when not given.
+func formatOf(format, path string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if format != itemfile.FormatCSV && format != itemfile.FormatJSON {
+		return "", errors.New("can't tell the format of the file, use -format csv or json")
+	}
+
+	return format, nil
+}
+
+func runImport(args []string) error {
+	var c connFlags
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	c.register(fs)
+	dryRun := fs.Bool("dry-run", false, "validate the file against the catalog without applying it")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+	path := fs.Arg(0)
+
+	format, err := formatOf(c.format, path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	client, closeConn, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	stream, err := client.ImportItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := &pb.ImportItemsRequest{Format: format, DryRun: *dryRun, LocationID: c.location}
+	// a failed send is explained by the status CloseAndRecv returns
+	if err := upload(stream, f, opts); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range res.Errors {
+		if e.ItemID != "" {
+			fmt.Fprintf(os.Stderr, "%s:%d: item %s: %s\n", path, e.Row, e.ItemID, e.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, e.Row, e.Message)
+		}
+	}
+
+	switch {
+	case len(res.Errors) > 0:
+		return fmt.Errorf("%d rows can't be imported, nothing was changed", len(res.Errors))
+	case res.Applied:
+		fmt.Printf("imported: %d created, %d updated, %d unchanged\n", res.Created, res.Updated, res.Unchanged)
+	default:
+		fmt.Printf("dry run: %d to create, %d to update, %d unchanged\n", res.Created, res.Updated, res.Unchanged)
+	}
+
+	return nil
+}
+
+// upload sends the options and then the file, a chunk at a time.
+func upload(stream pb.StockService_ImportItemsClient, r io.Reader, opts *pb.ImportItemsRequest) error {
+	if err := stream.Send(opts); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ImportItemsRequest{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func runExport(args []string) error {
+	var c connFlags
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	c.register(fs)
+	out := fs.String("o", "", "file to write, standard output when empty")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		usage()
+	}
+
+	format := c.format
+	if *out != "" || format != "" {
+		var err error
+		if format, err = formatOf(format, *out); err != nil {
+			return err
+		}
+	} else {
+		format = itemfile.FormatCSV
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	client, closeConn, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	stream, err := client.ExportItems(ctx, &pb.ExportItemsRequest{Format: format, LocationID: c.location})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return download(stream, os.Stdout)
+	}
+
+	// written next to the target and renamed, so a failed export leaves the
+	// previous file alone
+	tmp, err := os.CreateTemp(filepath.Dir(*out), filepath.Base(*out)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := download(stream, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), *out)
+}
+
+func download(stream pb.StockService_ExportItemsClient, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return bw.Flush()
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
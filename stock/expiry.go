@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// expiryWatcher publishes the lots expiring within the horizon every
+// interval, so the kitchen can use them up or write them off in time. Every
+// run lists all of them, consumers don't need to keep track of past events.
+type expiryWatcher struct {
+	service  StockService
+	alerts   *stockAlerts
+	horizon  time.Duration
+	interval time.Duration
+}
+
+func NewExpiryWatcher(service StockService, alerts *stockAlerts, horizon, interval time.Duration) *expiryWatcher {
+	return &expiryWatcher{service, alerts, horizon, interval}
+}
+
+func (w *expiryWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.publish(ctx)
+		}
+	}
+}
+
+func (w *expiryWatcher) publish(ctx context.Context) {
+	until := time.Now().Add(w.horizon)
+
+	lots, err := w.service.ExpiringLots(ctx, until)
+	if err != nil {
+		log.Printf("failed to list expiring lots: %v", err)
+		return
+	}
+	if len(lots) == 0 {
+		return
+	}
+
+	if err := w.alerts.PublishExpiring(ctx, until, lots); err != nil {
+		log.Printf("failed to publish %d expiring lots: %v", len(lots), err)
+		return
+	}
+
+	log.Printf("published %d lots expiring by %s", len(lots), until.Format(time.RFC3339))
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// loadFixture reads the items a fresh store is seeded with.
+func loadFixture(path string) ([]*Item, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*Item
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+
+	for _, item := range items {
+		if item.ID == "" {
+			return nil, fmt.Errorf("invalid fixture %s: item without an ID", path)
+		}
+	}
+
+	return items, nil
+}
@@ -1,10 +1,23 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"time"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxImportSize   = 8 << 20
+	exportChunkSize = 32 << 10
 )
 
 type StockGrpcHandler struct {
@@ -25,13 +38,224 @@ func NewGRPCHandler(
 }
 
 func (s *StockGrpcHandler) CheckIfItemIsInStock(ctx context.Context, p *pb.CheckIfItemIsInStockRequest) (*pb.CheckIfItemIsInStockResponse, error) {
-	inStock, items, err := s.service.CheckIfItemAreInStock(ctx, p.Items)
+	inStock, items, availability, err := s.service.CheckIfItemAreInStock(ctx, p.LocationID, p.Items)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.CheckIfItemIsInStockResponse{
-		InStock: inStock,
-		Items:   items,
+		InStock:      inStock,
+		Items:        items,
+		Availability: availability,
 	}, nil
 }
+
+func (s *StockGrpcHandler) ReserveItems(ctx context.Context, p *pb.ReserveItemsRequest) (*pb.Reservation, error) {
+	r, err := s.service.ReserveItems(ctx, p.OrderID, p.LocationID, p.Items, time.Duration(p.TTLSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) CommitReservation(ctx context.Context, p *pb.ReservationRequest) (*pb.Reservation, error) {
+	r, err := s.service.CommitReservation(ctx, p.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) ReleaseReservation(ctx context.Context, p *pb.ReservationRequest) (*pb.Reservation, error) {
+	r, err := s.service.ReleaseReservation(ctx, p.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) CreateItem(ctx context.Context, p *pb.StockItem) (*pb.StockItem, error) {
+	item, err := s.service.CreateItem(ctx, itemFromProto(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToStockItem(common.LocationOrDefault(p.LocationID)), nil
+}
+
+func (s *StockGrpcHandler) UpdateItem(ctx context.Context, p *pb.StockItem) (*pb.StockItem, error) {
+	item, err := s.service.UpdateItem(ctx, itemFromProto(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToStockItem(common.LocationOrDefault(p.LocationID)), nil
+}
+
+func (s *StockGrpcHandler) DeleteItem(ctx context.Context, p *pb.ItemRequest) (*pb.StockItem, error) {
+	item, err := s.service.DeleteItem(ctx, p.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToStockItem(common.DefaultLocation), nil
+}
+
+func (s *StockGrpcHandler) ListItems(ctx context.Context, p *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	items, next, err := s.service.ListItems(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListItemsResponse{
+		Items:         make([]*pb.StockItem, 0, len(items)),
+		NextPageToken: next,
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, item.ToStockItem(common.LocationOrDefault(p.LocationID)))
+	}
+
+	return res, nil
+}
+
+func (s *StockGrpcHandler) AdjustQuantity(ctx context.Context, p *pb.AdjustQuantityRequest) (*pb.StockItem, error) {
+	item, err := s.service.AdjustQuantity(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToStockItem(common.LocationOrDefault(p.LocationID)), nil
+}
+
+func (s *StockGrpcHandler) GetItemLedger(ctx context.Context, p *pb.GetItemLedgerRequest) (*pb.ItemLedger, error) {
+	l, err := s.service.GetItemLedger(ctx, p.ItemID, p.LocationID, int(p.PageSize), p.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) GetReorderSuggestions(ctx context.Context, p *pb.ReorderSuggestionsRequest) (*pb.ReorderSuggestions, error) {
+	suggestions, err := s.service.ReorderSuggestions(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) CreatePurchaseOrder(ctx context.Context, p *pb.CreatePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	po, err := s.service.CreatePurchaseOrder(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return po.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) GetPurchaseOrder(ctx context.Context, p *pb.PurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	po, err := s.service.GetPurchaseOrder(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return po.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) ListPurchaseOrders(ctx context.Context, p *pb.ListPurchaseOrdersRequest) (*pb.ListPurchaseOrdersResponse, error) {
+	orders, next, err := s.service.ListPurchaseOrders(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListPurchaseOrdersResponse{
+		PurchaseOrders: make([]*pb.PurchaseOrder, 0, len(orders)),
+		NextPageToken:  next,
+	}
+	for _, po := range orders {
+		res.PurchaseOrders = append(res.PurchaseOrders, po.ToProto())
+	}
+
+	return res, nil
+}
+
+func (s *StockGrpcHandler) ReceivePurchaseOrder(ctx context.Context, p *pb.ReceivePurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	po, err := s.service.ReceivePurchaseOrder(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return po.ToProto(), nil
+}
+
+func (s *StockGrpcHandler) CancelPurchaseOrder(ctx context.Context, p *pb.PurchaseOrderRequest) (*pb.PurchaseOrder, error) {
+	po, err := s.service.CancelPurchaseOrder(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return po.ToProto(), nil
+}
+
+// ImportItems reads the whole upload before applying it, the file is applied
+// in a single transaction anyway.
+func (s *StockGrpcHandler) ImportItems(stream pb.StockService_ImportItemsServer) error {
+	var (
+		opts *pb.ImportItemsRequest
+		file bytes.Buffer
+	)
+	for {
+		p, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if opts == nil {
+			opts = p
+		}
+		if file.Len()+len(p.Chunk) > maxImportSize {
+			return status.Errorf(codes.ResourceExhausted, "the file is larger than %d bytes", maxImportSize)
+		}
+		file.Write(p.Chunk)
+	}
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "nothing was uploaded")
+	}
+
+	res, err := s.service.ImportItems(stream.Context(), opts.Format, opts.LocationID, opts.DryRun, &file)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(res.ToProto())
+}
+
+func (s *StockGrpcHandler) ExportItems(p *pb.ExportItemsRequest, stream pb.StockService_ExportItemsServer) error {
+	w := bufio.NewWriterSize(chunkWriter(func(b []byte) error {
+		return stream.Send(&pb.ExportItemsChunk{Data: b})
+	}), exportChunkSize)
+
+	if err := s.service.ExportItems(stream.Context(), p.Format, p.LocationID, w); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// chunkWriter sends every write as a chunk of the stream.
+type chunkWriter func(b []byte) error
+
+func (w chunkWriter) Write(b []byte) (int, error) {
+	if err := w(b); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
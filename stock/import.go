@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "github.com/rikughi/commons/api"
+	"github.com/rikughi/omsv2-stock/itemfile"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const importReason = "catalog import"
+
+var (
+	// errImportRejected and errDryRun roll an import back, the result tells why
+	errImportRejected = errors.New("import rejected")
+	errDryRun         = errors.New("dry run")
+)
+
+// ImportResult tells what an import did, or would do on a dry run, to the
+// items of the file.
+type ImportResult struct {
+	Applied   bool
+	Created   int
+	Updated   int
+	Unchanged int
+	Errors    []ImportError
+}
+
+// ImportError is a row of the file that can't be applied.
+type ImportError struct {
+	Row     int
+	ItemID  string
+	Message string
+}
+
+func (r *ImportResult) reject(row itemfile.Row, err error) {
+	r.Errors = append(r.Errors, ImportError{Row: row.Number, ItemID: row.Record.ID, Message: status.Convert(err).Message()})
+}
+
+func (r *ImportResult) ToProto() *pb.ImportItemsResponse {
+	p := &pb.ImportItemsResponse{
+		Applied:   r.Applied,
+		Created:   int32(r.Created),
+		Updated:   int32(r.Updated),
+		Unchanged: int32(r.Unchanged),
+	}
+	for _, e := range r.Errors {
+		p.Errors = append(p.Errors, &pb.ImportError{Row: int32(e.Row), ItemID: e.ItemID, Message: e.Message})
+	}
+
+	return p
+}
+
+// ImportItems upserts the items of a catalog file in a single transaction:
+// new items are created, the others get the catalog details of the file and,
+// when it has one, its quantity on hand at the location. Every row is
+// checked before anything is written, and a file with any bad row isn't
+// applied at all.
+func (s *Service) ImportItems(ctx context.Context, format, location string, dryRun bool, r io.Reader) (*ImportResult, error) {
+	location, err := validateLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := itemfile.Read(format, r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s file: %v", format, err)
+	}
+	if len(rows) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the file has no items")
+	}
+
+	res := &ImportResult{}
+	items := make([]*Item, len(rows))
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		if row.Err != nil {
+			res.reject(row, row.Err)
+			continue
+		}
+		if first, ok := seen[row.Record.ID]; ok {
+			res.reject(row, fmt.Errorf("item %s is already on row %d", row.Record.ID, first))
+			continue
+		}
+		seen[row.Record.ID] = row.Number
+
+		item := itemFromRecord(row.Record)
+		if err := validateItem(item); err != nil {
+			res.reject(row, err)
+			continue
+		}
+		if row.Record.Quantity != nil && *row.Record.Quantity < 0 {
+			res.reject(row, status.Error(codes.InvalidArgument, "quantity can't be negative"))
+			continue
+		}
+		items[i] = item
+	}
+	if len(res.Errors) > 0 {
+		return res, nil
+	}
+
+	var entries []*LedgerEntry
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		// the store may run fn again on transient errors
+		*res = ImportResult{}
+		entries = nil
+
+		for i, row := range rows {
+			created, changed, e, err := s.importItem(ctx, location, items[i], row.Record)
+			if err != nil {
+				if _, ok := status.FromError(err); ok {
+					res.reject(row, err)
+					continue
+				}
+				return err
+			}
+
+			switch {
+			case created:
+				res.Created++
+			case changed:
+				res.Updated++
+			default:
+				res.Unchanged++
+			}
+			if e != nil {
+				entries = append(entries, e)
+			}
+		}
+
+		if len(res.Errors) > 0 {
+			return errImportRejected
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRejected) || errors.Is(err, errDryRun) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res.Applied = true
+	s.notifyAlerts(ctx, entries)
+	return res, nil
+}
+
+// importItem creates or updates the item of a record, returning the movement
+// that set its quantity if it took one. Errors the file is to blame for are
+// status errors.
+func (s *Service) importItem(ctx context.Context, location string, item *Item, r itemfile.Record) (created, changed bool, e *LedgerEntry, err error) {
+	quantity := r.Quantity
+
+	current, err := s.store.GetItem(ctx, item.ID)
+	if errors.Is(err, ErrItemNotFound) {
+		if quantity != nil && *quantity > 0 {
+			item.Levels = map[string]*StockLevel{location: {Quantity: *quantity}}
+		}
+		return true, true, nil, s.createItem(ctx, item, importReason)
+	}
+	if err != nil {
+		return false, false, nil, err
+	}
+
+	// the fields the record leaves out keep their values
+	if r.PriceID == nil {
+		item.PriceID = current.PriceID
+	}
+	if r.LowStockThreshold == nil {
+		item.LowStockThreshold = current.LowStockThreshold
+	}
+
+	if !sameCatalogDetails(current, item) {
+		// the file doesn't hold recipes, schedules, reorder points nor
+		// whether the item is only an ingredient
+		item.Recipe = current.Recipe
+		item.Schedule = current.Schedule
+		item.ReorderPoint = current.ReorderPoint
+		item.IngredientOnly = current.IngredientOnly
+		if err := s.store.UpdateItem(ctx, item); err != nil {
+			return false, false, nil, err
+		}
+		changed = true
+	}
+
+	if quantity == nil {
+		return false, changed, nil, nil
+	}
+
+	delta := *quantity - current.Level(location).Quantity
+	if delta == 0 {
+		return false, changed, nil, nil
+	}
+	if len(current.Recipe) > 0 {
+		return false, false, nil, status.Errorf(codes.FailedPrecondition, "item %s is made from ingredients, leave its quantity empty", item.ID)
+	}
+
+	e = newMovement(ctx, item.ID, location, MovementAdjustment, delta, 0, importReason)
+	err = s.applyMovement(ctx, e)
+	if errors.Is(err, ErrInsufficientStock) {
+		return false, false, nil, status.Errorf(codes.FailedPrecondition, "item %s has %d units reserved at %s, more than the quantity of %d", item.ID, current.Level(location).Reserved, location, *quantity)
+	}
+	if err != nil {
+		return false, false, nil, err
+	}
+
+	return false, true, e, nil
+}
+
+func sameCatalogDetails(a, b *Item) bool {
+	return a.Name == b.Name &&
+		a.PriceID == b.PriceID &&
+		a.UnitAmount == b.UnitAmount &&
+		a.Currency == b.Currency &&
+		a.LowStockThreshold == b.LowStockThreshold
+}
+
+// ExportItems writes the whole catalog with the quantities on hand at the
+// location, in the format ImportItems reads.
+func (s *Service) ExportItems(ctx context.Context, format, location string, w io.Writer) error {
+	location, err := validateLocation(location)
+	if err != nil {
+		return err
+	}
+
+	fw, err := itemfile.NewWriter(format, w)
+	if errors.Is(err, itemfile.ErrUnknownFormat) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	var after string
+	for {
+		items, err := s.store.ListItems(ctx, ListItemsFilter{After: after, Limit: MaxPageSize})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := fw.Write(item.toRecord(location)); err != nil {
+				return err
+			}
+		}
+
+		if len(items) < MaxPageSize {
+			return fw.Close()
+		}
+		after = items[len(items)-1].ID
+	}
+}
+
+func itemFromRecord(r itemfile.Record) *Item {
+	item := &Item{
+		ID:         r.ID,
+		Name:       r.Name,
+		UnitAmount: r.UnitAmount,
+		Currency:   r.Currency,
+	}
+	if r.PriceID != nil {
+		item.PriceID = *r.PriceID
+	}
+	if r.LowStockThreshold != nil {
+		item.LowStockThreshold = *r.LowStockThreshold
+	}
+
+	return item
+}
+
+func (i *Item) toRecord(location string) itemfile.Record {
+	threshold := i.LowStockThreshold
+	r := itemfile.Record{
+		ID:                i.ID,
+		Name:              i.Name,
+		UnitAmount:        i.UnitAmount,
+		Currency:          i.Currency,
+		LowStockThreshold: &threshold,
+	}
+	if i.PriceID != "" {
+		r.PriceID = &i.PriceID
+	}
+	if len(i.Recipe) == 0 {
+		quantity := i.Level(location).Quantity
+		r.Quantity = &quantity
+	}
+
+	return r
+}
@@ -0,0 +1,279 @@
+// Package itemfile reads and writes the stock catalog as the CSV or JSON
+// files merchandisers keep the menu in. Both formats hold the same records,
+// so an exported file can be edited and imported back.
+package itemfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Record is an item of a catalog file. Recipes, schedules and reorder points
+// are not part of the file, they are kept as they are when an item is
+// imported. So are the optional fields left nil, which are the empty cells
+// and missing columns of a CSV file and the missing fields of a JSON one.
+type Record struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	PriceID    *string `json:"priceID,omitempty"`
+	UnitAmount int64   `json:"unitAmount"`
+	Currency   string  `json:"currency"`
+	// Quantity is the quantity on hand. Items made from ingredients have none.
+	Quantity          *int32 `json:"quantity,omitempty"`
+	LowStockThreshold *int32 `json:"lowStockThreshold,omitempty"`
+}
+
+// Row is a record as read from a file, or the reason it couldn't be.
+type Row struct {
+	// Number is the line of a CSV file, or the position of the record in a
+	// JSON file, counting from 1
+	Number int
+	Record Record
+	Err    error
+}
+
+// columns of a CSV file, in the order they are written
+var columns = []string{"id", "name", "price_id", "unit_amount", "currency", "quantity", "low_stock_threshold"}
+
+// requiredColumns must be in the header of a CSV file, the others may be
+// left out.
+var requiredColumns = []string{"id", "name", "unit_amount", "currency"}
+
+var ErrUnknownFormat = errors.New("unknown format, use csv or json")
+
+// Read reads the records of a file. It fails when the file can't be read as
+// a whole, errors that only affect a record are reported on its row.
+func Read(format string, r io.Reader) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		return readJSON(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(columns, name) {
+			return nil, fmt.Errorf("unknown column %q, use %s", name, strings.Join(columns, ", "))
+		}
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("column %q is listed twice", name)
+		}
+		index[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("column %q is missing", name)
+		}
+	}
+
+	var rows []Row
+	for {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			rows = append(rows, Row{Number: parseErr.StartLine, Err: fmt.Errorf("expected %d fields, found %d", len(header), len(fields))})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		rows = append(rows, csvRow(line, index, fields))
+	}
+}
+
+func csvRow(line int, index map[string]int, fields []string) Row {
+	row := Row{Number: line}
+	field := func(name string) string {
+		if i, ok := index[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	row.Record = Record{
+		ID:       field("id"),
+		Name:     field("name"),
+		Currency: field("currency"),
+	}
+
+	if v := field("price_id"); v != "" {
+		row.Record.PriceID = &v
+	}
+
+	var err error
+	if row.Record.UnitAmount, err = strconv.ParseInt(field("unit_amount"), 10, 64); err != nil {
+		row.Err = fmt.Errorf("invalid unit_amount %q", field("unit_amount"))
+		return row
+	}
+
+	if row.Record.Quantity, err = optionalInt32(field("quantity")); err != nil {
+		row.Err = fmt.Errorf("invalid quantity %q", field("quantity"))
+		return row
+	}
+	if row.Record.LowStockThreshold, err = optionalInt32(field("low_stock_threshold")); err != nil {
+		row.Err = fmt.Errorf("invalid low_stock_threshold %q", field("low_stock_threshold"))
+		return row
+	}
+
+	return row
+}
+
+func optionalInt32(v string) (*int32, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	n32 := int32(n)
+	return &n32, nil
+}
+
+func readJSON(r io.Reader) ([]Row, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("expected an array of items: %w", err)
+	}
+
+	rows := make([]Row, 0, len(raw))
+	for i, b := range raw {
+		row := Row{Number: i + 1}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&row.Record); err != nil {
+			// keep the ID to report the error with, if there is one
+			row.Record = Record{}
+			json.Unmarshal(b, &struct {
+				ID *string `json:"id"`
+			}{&row.Record.ID})
+			row.Err = err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Writer writes the records of a file. Close must be called to complete it.
+type Writer interface {
+	Write(r Record) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		return &csvWriter{w: cw}, cw.Write(columns)
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(r Record) error {
+	var priceID string
+	if r.PriceID != nil {
+		priceID = *r.PriceID
+	}
+
+	return w.w.Write([]string{
+		r.ID,
+		r.Name,
+		priceID,
+		strconv.FormatInt(r.UnitAmount, 10),
+		r.Currency,
+		formatOptional(r.Quantity),
+		formatOptional(r.LowStockThreshold),
+	})
+}
+
+func formatOptional(n *int32) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*n))
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// jsonWriter writes an array with one record per line, which keeps large
+// exports streaming and diffable.
+type jsonWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (w *jsonWriter) Write(r Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n  "
+	if !w.written {
+		sep = "[\n  "
+		w.written = true
+	}
+
+	_, err = fmt.Fprintf(w.w, "%s%s", sep, b)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	end := "\n]\n"
+	if !w.written {
+		end = "[]\n"
+	}
+
+	_, err := io.WriteString(w.w, end)
+	return err
+}
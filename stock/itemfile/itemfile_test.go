@@ -0,0 +1,96 @@
+package itemfile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		file    string
+		wantErr string
+	}{
+		{name: "unknown format", format: "xml", file: "<items/>", wantErr: ErrUnknownFormat.Error()},
+		{name: "empty csv", format: FormatCSV, file: "", wantErr: "the file is empty"},
+		{name: "unknown column", format: FormatCSV, file: "id,name,unit_amount,currency,color\n", wantErr: `unknown column "color"`},
+		{name: "column listed twice", format: FormatCSV, file: "id,name,unit_amount,currency,Name\n", wantErr: `column "name" is listed twice`},
+		{name: "missing column", format: FormatCSV, file: "id,name,currency\n", wantErr: `column "unit_amount" is missing`},
+		{name: "json not an array", format: FormatJSON, file: `{"id":"chips"}`, wantErr: "expected an array of items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.format, strings.NewReader(tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Read() error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadRowErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		file   string
+		// want is the error of each row, empty for the rows that were read
+		want []string
+		// wantIDs are the IDs the rows are reported with
+		wantIDs []string
+	}{
+		{
+			name:    "csv rows",
+			format:  FormatCSV,
+			file:    "id,name,unit_amount,currency,quantity\nchips,Chips,250,usd,10\nsoda,Soda,1.50,usd,5\nfries,Fries,300,usd,lots\n",
+			want:    []string{"", `invalid unit_amount "1.50"`, `invalid quantity "lots"`},
+			wantIDs: []string{"chips", "soda", "fries"},
+		},
+		{
+			name:    "csv field count",
+			format:  FormatCSV,
+			file:    "id,name,unit_amount,currency\nchips,Chips,250\nsoda,Soda,150,usd\n",
+			want:    []string{"expected 4 fields, found 3", ""},
+			wantIDs: []string{"", "soda"},
+		},
+		{
+			name:    "json unknown field",
+			format:  FormatJSON,
+			file:    `[{"id":"chips","name":"Chips","unitAmount":250,"currency":"usd"},{"id":"soda","price":150}]`,
+			want:    []string{"", `unknown field "price"`},
+			wantIDs: []string{"chips", "soda"},
+		},
+		{
+			name:    "json wrong type",
+			format:  FormatJSON,
+			file:    `[{"id":"soda","unitAmount":"150"}]`,
+			want:    []string{"cannot unmarshal string"},
+			wantIDs: []string{"soda"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Read(tt.format, strings.NewReader(tt.file))
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("read %d rows, want %d", len(rows), len(tt.want))
+			}
+
+			for i, row := range rows {
+				switch {
+				case tt.want[i] == "" && row.Err != nil:
+					t.Errorf("row %d: unexpected error %v", row.Number, row.Err)
+				case tt.want[i] != "" && (row.Err == nil || !strings.Contains(row.Err.Error(), tt.want[i])):
+					t.Errorf("row %d: error %v, want %q", row.Number, row.Err, tt.want[i])
+				}
+				if row.Record.ID != tt.wantIDs[i] {
+					t.Errorf("row %d: ID %q, want %q", row.Number, row.Record.ID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MovementReservation = "reservation"
+	MovementCommit      = "commit"
+	MovementRelease     = "release"
+	MovementAdjustment  = "adjustment"
+	MovementRestock     = "restock"
+)
+
+// LedgerEntry is an immutable record of a change to an item's quantities at
+// a location. The on hand and reserved quantities of an item at a location
+// always equal the sum of its entries there.
+type LedgerEntry struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	ItemID        string             `bson:"itemID"`
+	LocationID    string             `bson:"locationID"`
+	Type          string             `bson:"type"`
+	QuantityDelta int32              `bson:"quantityDelta"`
+	ReservedDelta int32              `bson:"reservedDelta"`
+	// balances of the item at the location once the entry was applied
+	Quantity      int32     `bson:"quantity"`
+	Reserved      int32     `bson:"reserved"`
+	Reason        string    `bson:"reason,omitempty"`
+	OrderID       string    `bson:"orderID,omitempty"`
+	ReservationID string    `bson:"reservationID,omitempty"`
+	Actor         string    `bson:"actor,omitempty"`
+	At            time.Time `bson:"at"`
+	// Lots are the lots the quantity moved in or out of, stock outside of
+	// lots is left out
+	Lots []LotMovement `bson:"lots,omitempty"`
+}
+
+func (e *LedgerEntry) ToProto() *pb.LedgerEntry {
+	lots := make([]*pb.LotMovement, 0, len(e.Lots))
+	for _, m := range e.Lots {
+		lots = append(lots, m.ToProto())
+	}
+
+	return &pb.LedgerEntry{
+		ID:            e.ID.Hex(),
+		ItemID:        e.ItemID,
+		LocationID:    e.LocationID,
+		Type:          e.Type,
+		QuantityDelta: e.QuantityDelta,
+		ReservedDelta: e.ReservedDelta,
+		Quantity:      e.Quantity,
+		Reserved:      e.Reserved,
+		Reason:        e.Reason,
+		OrderID:       e.OrderID,
+		ReservationID: e.ReservationID,
+		Actor:         e.Actor,
+		Timestamp:     e.At.Unix(),
+		Lots:          lots,
+	}
+}
+
+// newMovement describes a change made within ctx. The actor is the service
+// that called us, stock itself when the change didn't come through gRPC. An
+// empty reason falls back to the one the caller gave.
+// checkBalances makes sure the balances e leaves the item with are the sum
+// of its ledger, quantity and reserved being what the ledger adds up to
+// before e.
+func checkBalances(e *LedgerEntry, quantity, reserved int32) error {
+	if quantity+e.QuantityDelta == e.Quantity && reserved+e.ReservedDelta == e.Reserved {
+		return nil
+	}
+
+	return fmt.Errorf("%w: item %s at %s would have %d on hand and %d reserved, its ledger %d and %d",
+		ErrLedgerDrift, e.ItemID, e.LocationID, e.Quantity, e.Reserved, quantity+e.QuantityDelta, reserved+e.ReservedDelta)
+}
+
+func newMovement(ctx context.Context, itemID, location, kind string, quantityDelta, reservedDelta int32, reason string) *LedgerEntry {
+	actor, callerReason := common.ActorFromIncomingContext(ctx)
+	if actor == "" {
+		actor = "stock"
+	}
+	if reason == "" {
+		reason = callerReason
+	}
+
+	return &LedgerEntry{
+		ID:            primitive.NewObjectID(),
+		ItemID:        itemID,
+		LocationID:    location,
+		Type:          kind,
+		QuantityDelta: quantityDelta,
+		ReservedDelta: reservedDelta,
+		Reason:        reason,
+		Actor:         actor,
+		At:            time.Now(),
+	}
+}
+
+// reservationMovement describes a change to the items of a reservation, at
+// the location it holds them.
+func reservationMovement(ctx context.Context, r *Reservation, itemID, kind string, quantityDelta, reservedDelta int32, reason string) *LedgerEntry {
+	e := newMovement(ctx, itemID, r.LocationID, kind, quantityDelta, reservedDelta, reason)
+	e.OrderID = r.OrderID
+	e.ReservationID = r.ID
+
+	return e
+}
+
+// ItemLedger is a page of an item's ledger at a location along with the
+// balances derived from all of its entries there.
+type ItemLedger struct {
+	ItemID        string
+	LocationID    string
+	OnHand        int32
+	Reserved      int32
+	Entries       []*LedgerEntry
+	NextPageToken string
+}
+
+func (l *ItemLedger) ToProto() *pb.ItemLedger {
+	entries := make([]*pb.LedgerEntry, 0, len(l.Entries))
+	for _, e := range l.Entries {
+		entries = append(entries, e.ToProto())
+	}
+
+	return &pb.ItemLedger{
+		ItemID:        l.ItemID,
+		LocationID:    l.LocationID,
+		OnHand:        l.OnHand,
+		Reserved:      l.Reserved,
+		Entries:       entries,
+		NextPageToken: l.NextPageToken,
+	}
+}
+
+func (s *Service) GetItemLedger(ctx context.Context, itemID, location string, pageSize int, pageToken string) (*ItemLedger, error) {
+	location, err := validateLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	var after primitive.ObjectID
+	if pageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(pageToken)
+		if err != nil || len(b) != len(after) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		copy(after[:], b)
+	}
+
+	onHand, reserved, err := s.store.SumLedger(ctx, itemID, location)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra entry to know whether there is a next page
+	entries, err := s.store.ListLedger(ctx, itemID, location, after, int64(pageSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 && after.IsZero() {
+		// items that never moved have no entries, make sure it exists at all
+		if _, err := s.store.GetItem(ctx, itemID); err != nil {
+			return nil, stockError(itemID, err)
+		}
+	}
+
+	l := &ItemLedger{
+		ItemID:     itemID,
+		LocationID: location,
+		OnHand:     onHand,
+		Reserved:   reserved,
+		Entries:    entries,
+	}
+	if len(entries) > pageSize {
+		l.Entries = entries[:pageSize]
+		last := l.Entries[pageSize-1].ID
+		l.NextPageToken = base64.RawURLEncoding.EncodeToString(last[:])
+	}
+
+	return l, nil
+}
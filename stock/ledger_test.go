@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	common "github.com/rikughi/commons"
+)
+
+func TestApplyMovementChecksTheLedger(t *testing.T) {
+	tests := []struct {
+		name string
+		// drift changes the level behind the ledger's back
+		drift   func(level *StockLevel)
+		wantErr error
+	}{
+		{name: "level matches the ledger", drift: func(*StockLevel) {}},
+		{name: "quantity changed in place", drift: func(level *StockLevel) { level.Quantity += 2 }, wantErr: ErrLedgerDrift},
+		{name: "reserved changed in place", drift: func(level *StockLevel) { level.Reserved++ }, wantErr: ErrLedgerDrift},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(t, stockedItem("chips", 10))
+			store := svc.store.(*MemoryStore)
+
+			tt.drift(store.stock["chips"].Levels[common.DefaultLocation])
+			recorded := len(store.ledger)
+
+			e := newMovement(ctx, "chips", common.DefaultLocation, MovementAdjustment, -1, 0, "counted")
+			if err := store.ApplyMovement(ctx, e); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyMovement: %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+
+			if len(store.ledger) != recorded {
+				t.Errorf("the failed movement was recorded in the ledger")
+			}
+		})
+	}
+}
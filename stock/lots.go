@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lot is stock of an item received at once, which expires together. The
+// quantity of a level its lots don't account for, such as stock found by a
+// count or held before lots existed, doesn't expire.
+type Lot struct {
+	ID         string    `bson:"id" json:"id"`
+	Quantity   int32     `bson:"quantity" json:"quantity"`
+	ReceivedAt time.Time `bson:"receivedAt" json:"receivedAt"`
+	// ExpiresAt is nil for stock that doesn't expire
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+}
+
+// ExpiredAt tells whether the lot can no longer be sold at t.
+func (l *Lot) ExpiredAt(t time.Time) bool {
+	return l.ExpiresAt != nil && !l.ExpiresAt.After(t)
+}
+
+func (l *Lot) ToProto(now time.Time) *pb.Lot {
+	p := &pb.Lot{
+		ID:         l.ID,
+		Quantity:   l.Quantity,
+		ReceivedAt: l.ReceivedAt.Unix(),
+		Expired:    l.ExpiredAt(now),
+	}
+	if l.ExpiresAt != nil {
+		p.ExpiresAt = l.ExpiresAt.Unix()
+	}
+
+	return p
+}
+
+// LotMovement is the part of a movement that went in or out of a lot. A
+// movement with NewLot received the lot.
+type LotMovement struct {
+	LotID         string     `bson:"lotID"`
+	QuantityDelta int32      `bson:"quantityDelta"`
+	ExpiresAt     *time.Time `bson:"expiresAt,omitempty"`
+	NewLot        bool       `bson:"newLot,omitempty"`
+}
+
+func (m LotMovement) ToProto() *pb.LotMovement {
+	p := &pb.LotMovement{
+		LotID:         m.LotID,
+		QuantityDelta: m.QuantityDelta,
+		NewLot:        m.NewLot,
+	}
+	if m.ExpiresAt != nil {
+		p.ExpiresAt = m.ExpiresAt.Unix()
+	}
+
+	return p
+}
+
+// Expired is the quantity on hand in lots expired at t.
+func (l StockLevel) Expired(t time.Time) int32 {
+	var expired int32
+	for _, lot := range l.Lots {
+		if lot.ExpiredAt(t) {
+			expired += lot.Quantity
+		}
+	}
+
+	return expired
+}
+
+// lot finds a lot of the level by ID.
+func (l StockLevel) lot(id string) *Lot {
+	for _, lot := range l.Lots {
+		if lot.ID == id {
+			return lot
+		}
+	}
+
+	return nil
+}
+
+// takeLots picks the lots n units leave from, first expired first out: the
+// earliest expiring lots go first and the ones that don't expire last, in
+// the order they were received. Stock outside of lots is the oldest there
+// is. With sellable, lots expired at t are only taken once nothing else is
+// left, which only happens to reservations that outlived their lots.
+func (l StockLevel) takeLots(n int32, t time.Time, sellable bool) []LotMovement {
+	lots := make([]*Lot, 0, len(l.Lots))
+	for _, lot := range l.Lots {
+		if lot.Quantity > 0 {
+			lots = append(lots, lot)
+		}
+	}
+	sort.SliceStable(lots, func(i, j int) bool {
+		a, b := lots[i], lots[j]
+		if sellable && a.ExpiredAt(t) != b.ExpiredAt(t) {
+			return !a.ExpiredAt(t)
+		}
+		if (a.ExpiresAt == nil) != (b.ExpiresAt == nil) {
+			return a.ExpiresAt != nil
+		}
+		if a.ExpiresAt != nil && !a.ExpiresAt.Equal(*b.ExpiresAt) {
+			return a.ExpiresAt.Before(*b.ExpiresAt)
+		}
+		return a.ReceivedAt.Before(b.ReceivedAt)
+	})
+
+	untracked := l.Quantity
+	for _, lot := range lots {
+		untracked -= lot.Quantity
+	}
+
+	var moves []LotMovement
+	take := func(lot *Lot) {
+		q := min(n, lot.Quantity)
+		moves = append(moves, LotMovement{LotID: lot.ID, QuantityDelta: -q})
+		n -= q
+	}
+
+	for _, lot := range lots {
+		if n == 0 {
+			break
+		}
+		if lot.ExpiresAt == nil || (sellable && lot.ExpiredAt(t)) {
+			// stock outside of lots comes before these
+			n -= min(n, max(untracked, 0))
+			untracked = 0
+			if n == 0 {
+				break
+			}
+		}
+		take(lot)
+	}
+
+	return moves
+}
+
+// receiveLot describes a delivery of quantity units into the lot id of a
+// level, a new lot when id is empty or the level doesn't have it.
+func receiveLot(l StockLevel, id string, quantity int32, expiresAt *time.Time) (LotMovement, error) {
+	if id == "" {
+		id = primitive.NewObjectID().Hex()
+	}
+
+	lot := l.lot(id)
+	if lot == nil {
+		return LotMovement{LotID: id, QuantityDelta: quantity, ExpiresAt: expiresAt, NewLot: true}, nil
+	}
+
+	same := lot.ExpiresAt == nil && expiresAt == nil ||
+		lot.ExpiresAt != nil && expiresAt != nil && lot.ExpiresAt.Equal(*expiresAt)
+	if !same {
+		return LotMovement{}, status.Errorf(codes.FailedPrecondition, "lot %s has another expiry date", id)
+	}
+
+	return LotMovement{LotID: id, QuantityDelta: quantity}, nil
+}
+
+// applyMovement applies e to the store, taking the stock it removes from the
+// lots first expired first out and receiving restocks into a new lot unless
+// they name one.
+func (s *Service) applyMovement(ctx context.Context, e *LedgerEntry) error {
+	switch {
+	case e.QuantityDelta < 0:
+		item, err := s.store.GetItem(ctx, e.ItemID)
+		if err != nil {
+			return err
+		}
+		e.Lots = item.Level(e.LocationID).takeLots(-e.QuantityDelta, e.At, e.Type == MovementCommit)
+	case e.Type == MovementRestock && len(e.Lots) == 0:
+		lot, _ := receiveLot(StockLevel{}, "", e.QuantityDelta, nil)
+		e.Lots = []LotMovement{lot}
+	}
+
+	return s.store.ApplyMovement(ctx, e)
+}
+
+// ExpiringLot is a lot along with the item and location it belongs to.
+type ExpiringLot struct {
+	Item       *Item
+	LocationID string
+	Lot        *Lot
+}
+
+// ExpiringLots lists the lots with stock left that expire by until, the
+// earliest expiring first. The catalog is small enough to go through it
+// whole.
+func (s *Service) ExpiringLots(ctx context.Context, until time.Time) ([]*ExpiringLot, error) {
+	var (
+		expiring []*ExpiringLot
+		after    string
+	)
+	for {
+		items, err := s.store.ListItems(ctx, ListItemsFilter{After: after, Limit: MaxPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			for _, location := range item.locations() {
+				for _, lot := range item.Level(location).Lots {
+					if lot.Quantity > 0 && lot.ExpiredAt(until) {
+						expiring = append(expiring, &ExpiringLot{Item: item, LocationID: location, Lot: lot})
+					}
+				}
+			}
+		}
+
+		if len(items) < MaxPageSize {
+			break
+		}
+		after = items[len(items)-1].ID
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].Lot.ExpiresAt.Before(*expiring[j].Lot.ExpiresAt)
+	})
+
+	return expiring, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTakeLots(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		t := now.AddDate(0, 0, days)
+		return &t
+	}
+	received := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	tests := []struct {
+		name     string
+		level    StockLevel
+		n        int32
+		sellable bool
+		want     []LotMovement
+	}{
+		{
+			name: "earliest expiry first",
+			level: StockLevel{Quantity: 10, Lots: []*Lot{
+				{ID: "late", Quantity: 5, ReceivedAt: received(3), ExpiresAt: at(5)},
+				{ID: "early", Quantity: 5, ReceivedAt: received(1), ExpiresAt: at(2)},
+			}},
+			n: 7,
+			want: []LotMovement{
+				{LotID: "early", QuantityDelta: -5},
+				{LotID: "late", QuantityDelta: -2},
+			},
+		},
+		{
+			name: "same expiry oldest received first",
+			level: StockLevel{Quantity: 6, Lots: []*Lot{
+				{ID: "new", Quantity: 3, ReceivedAt: received(1), ExpiresAt: at(2)},
+				{ID: "old", Quantity: 3, ReceivedAt: received(2), ExpiresAt: at(2)},
+			}},
+			n: 4,
+			want: []LotMovement{
+				{LotID: "old", QuantityDelta: -3},
+				{LotID: "new", QuantityDelta: -1},
+			},
+		},
+		{
+			name: "lots that don't expire last",
+			level: StockLevel{Quantity: 6, Lots: []*Lot{
+				{ID: "forever", Quantity: 3, ReceivedAt: received(5)},
+				{ID: "perishable", Quantity: 3, ReceivedAt: received(1), ExpiresAt: at(1)},
+			}},
+			n: 4,
+			want: []LotMovement{
+				{LotID: "perishable", QuantityDelta: -3},
+				{LotID: "forever", QuantityDelta: -1},
+			},
+		},
+		{
+			name: "stock outside of lots before lots that don't expire",
+			level: StockLevel{Quantity: 8, Lots: []*Lot{
+				{ID: "forever", Quantity: 3, ReceivedAt: received(5)},
+				{ID: "perishable", Quantity: 3, ReceivedAt: received(1), ExpiresAt: at(1)},
+			}},
+			n: 6,
+			want: []LotMovement{
+				{LotID: "perishable", QuantityDelta: -3},
+				{LotID: "forever", QuantityDelta: -1},
+			},
+		},
+		{
+			name: "expired lots first when not selling",
+			level: StockLevel{Quantity: 6, Lots: []*Lot{
+				{ID: "fresh", Quantity: 3, ReceivedAt: received(1), ExpiresAt: at(3)},
+				{ID: "expired", Quantity: 3, ReceivedAt: received(9), ExpiresAt: at(-1)},
+			}},
+			n: 4,
+			want: []LotMovement{
+				{LotID: "expired", QuantityDelta: -3},
+				{LotID: "fresh", QuantityDelta: -1},
+			},
+		},
+		{
+			name: "expired lots last when selling",
+			level: StockLevel{Quantity: 6, Lots: []*Lot{
+				{ID: "fresh", Quantity: 3, ReceivedAt: received(1), ExpiresAt: at(3)},
+				{ID: "expired", Quantity: 3, ReceivedAt: received(9), ExpiresAt: at(-1)},
+			}},
+			n:        4,
+			sellable: true,
+			want: []LotMovement{
+				{LotID: "fresh", QuantityDelta: -3},
+				{LotID: "expired", QuantityDelta: -1},
+			},
+		},
+		{
+			name:  "no lots",
+			level: StockLevel{Quantity: 5},
+			n:     3,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.level.takeLots(tt.n, now, tt.sellable)
+			if !slices.EqualFunc(got, tt.want, func(a, b LotMovement) bool {
+				return a.LotID == b.LotID && a.QuantityDelta == b.QuantityDelta
+			}) {
+				t.Errorf("takeLots(%d) = %+v, want %+v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,14 +3,19 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"strconv"
 	"time"
 
 	common "github.com/rikughi/commons"
+	"github.com/rikughi/commons/broker"
 	"github.com/rikughi/commons/discovery"
 	"github.com/rikughi/commons/discovery/consul"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"google.golang.org/grpc"
 )
 
@@ -18,6 +23,24 @@ var (
 	serviceName = "stock"
 	port        = 2011
 	consulAddr  = common.EnvString("CONSUL_ADDR", "localhost:8500")
+	// mongo or memory, the latter loses the stock on restart
+	storeKind = common.EnvString("STOCK_STORE", "mongo")
+	fixtures  = common.EnvString("STOCK_FIXTURES", "fixtures/items.json")
+	mongoUser = common.EnvString("MONGO_DB_USER", "root")
+	mongoPass = common.EnvString("MONGO_DB_PASS", "example")
+	mongoAddr = common.EnvString("MONGO_DB_HOST", "localhost:27017")
+	// reservations that don't set their own TTL hold items this long
+	reservationTTL = common.EnvString("STOCK_RESERVATION_TTL", "15m")
+	sweepInterval  = common.EnvString("STOCK_RESERVATION_SWEEP_INTERVAL", "1m")
+	// an item alerts for the same threshold at most once per window
+	alertDebounce = common.EnvString("STOCK_ALERT_DEBOUNCE", "10m")
+	amqpUser      = common.EnvString("RABBITMQ_USER", "guest")
+	amqpPass      = common.EnvString("RABBITMQ_PASS", "guest")
+	amqpHost      = common.EnvString("RABBITMQ_HOST", "localhost")
+	amqpPort      = common.EnvString("RABBITMQ_PORT", "5672")
+	// stock.expiring lists the lots expiring within the horizon every interval
+	expiryHorizon  = common.EnvString("STOCK_EXPIRY_HORIZON", "48h")
+	expiryInterval = common.EnvString("STOCK_EXPIRY_INTERVAL", "1h")
 )
 
 func main() {
@@ -48,6 +71,12 @@ func main() {
 
 	defer registry.Deregister(ctx, instanceID, serviceName)
 
+	ch, close := broker.Connect(amqpUser, amqpPass, amqpHost, amqpPort)
+	defer func() {
+		close()
+		ch.Close()
+	}()
+
 	grpcServer := grpc.NewServer()
 
 	l, err := net.Listen("tcp", grpcAddr)
@@ -56,13 +85,88 @@ func main() {
 	}
 	defer l.Close()
 
-	store := NewStore()
-	svc := NewService(store)
+	store, err := newStockStore()
+	if err != nil {
+		log.Fatalf("failed to create the stock store: %v", err)
+	}
+
+	ttl, err := time.ParseDuration(reservationTTL)
+	if err != nil {
+		log.Fatalf("invalid STOCK_RESERVATION_TTL: %v", err)
+	}
+	interval, err := time.ParseDuration(sweepInterval)
+	if err != nil {
+		log.Fatalf("invalid STOCK_RESERVATION_SWEEP_INTERVAL: %v", err)
+	}
+	debounce, err := time.ParseDuration(alertDebounce)
+	if err != nil {
+		log.Fatalf("invalid STOCK_ALERT_DEBOUNCE: %v", err)
+	}
+
+	horizon, err := time.ParseDuration(expiryHorizon)
+	if err != nil {
+		log.Fatalf("invalid STOCK_EXPIRY_HORIZON: %v", err)
+	}
+	expiryEvery, err := time.ParseDuration(expiryInterval)
+	if err != nil {
+		log.Fatalf("invalid STOCK_EXPIRY_INTERVAL: %v", err)
+	}
+
+	alerts := NewStockAlerts(ch, debounce)
+	svc := NewService(store, ttl, alerts)
+
+	items, err := loadFixture(fixtures)
+	if err != nil {
+		log.Fatalf("failed to load fixtures: %v", err)
+	}
+	if err := svc.Seed(ctx, items); err != nil {
+		log.Fatalf("failed to seed the stock: %v", err)
+	}
+
 	NewGRPCHandler(grpcServer, svc)
 
+	sweeper := NewReservationSweeper(svc, interval)
+	go sweeper.Run(ctx)
+
+	expiry := NewExpiryWatcher(svc, alerts, horizon, expiryEvery)
+	go expiry.Run(ctx)
+
 	log.Println("GRPC Server Started at ", grpcAddr)
 
 	if err := grpcServer.Serve(l); err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+func newStockStore() (StockStore, error) {
+	switch storeKind {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "mongo":
+		uri := fmt.Sprintf("mongodb://%s:%s@%s", mongoUser, mongoPass, mongoAddr)
+		client, err := connectToMongoDB(uri)
+		if err != nil {
+			return nil, err
+		}
+
+		store := NewStore(client)
+		if err := store.EnsureIndexes(context.Background()); err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown stock store %q", storeKind)
+	}
+}
+
+func connectToMongoDB(uri string) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Ping(ctx, readpref.Primary())
+	return client, err
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryStore keeps the stock in memory. It is meant for tests and local
+// runs, everything is lost on restart.
+type MemoryStore struct {
+	mu           sync.RWMutex
+	stock        map[string]*Item
+	reservations map[string]*Reservation
+	orders       map[string]*PurchaseOrder
+	// ledger is append only, in the order the entries were applied
+	ledger []*LedgerEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		stock:        make(map[string]*Item),
+		reservations: make(map[string]*Reservation),
+		orders:       make(map[string]*PurchaseOrder),
+	}
+}
+
+type memoryTxKey struct{}
+
+// lock takes the write lock, unless ctx belongs to a transaction of this
+// store which already holds it.
+func (s *MemoryStore) lock(ctx context.Context) func() {
+	if ctx.Value(memoryTxKey{}) == s {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *MemoryStore) rlock(ctx context.Context) func() {
+	if ctx.Value(memoryTxKey{}) == s {
+		return func() {}
+	}
+
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+func (s *MemoryStore) GetItem(ctx context.Context, id string) (*Item, error) {
+	defer s.rlock(ctx)()
+
+	item, ok := s.stock[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+
+	// hand out copies so callers never race with writers
+	return cloneItem(item), nil
+}
+
+func (s *MemoryStore) GetItems(ctx context.Context, ids []string) ([]*Item, error) {
+	defer s.rlock(ctx)()
+
+	var res []*Item
+	for _, id := range ids {
+		if item, ok := s.stock[id]; ok {
+			res = append(res, cloneItem(item))
+		}
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) CreateItem(ctx context.Context, item *Item) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.stock[item.ID]; ok {
+		return ErrItemExists
+	}
+
+	s.stock[item.ID] = cloneItem(item)
+	return nil
+}
+
+func (s *MemoryStore) UpdateItem(ctx context.Context, item *Item) error {
+	defer s.lock(ctx)()
+
+	current, ok := s.stock[item.ID]
+	if !ok {
+		return ErrItemNotFound
+	}
+
+	current.Name = item.Name
+	current.PriceID = item.PriceID
+	current.UnitAmount = item.UnitAmount
+	current.Currency = item.Currency
+	current.LowStockThreshold = item.LowStockThreshold
+	current.ReorderPoint = item.ReorderPoint
+	current.IngredientOnly = item.IngredientOnly
+	current.Recipe = slices.Clone(item.Recipe)
+	current.Schedule = item.Schedule
+	return nil
+}
+
+func (s *MemoryStore) DeleteItem(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.stock[id]; !ok {
+		return ErrItemNotFound
+	}
+
+	delete(s.stock, id)
+	return nil
+}
+
+func (s *MemoryStore) ListItems(ctx context.Context, f ListItemsFilter) ([]*Item, error) {
+	defer s.rlock(ctx)()
+
+	ids := make([]string, 0, len(s.stock))
+	for id, item := range s.stock {
+		if id <= f.After || (f.ExcludeDepleted && item.Level(f.Location).Quantity <= 0 && len(item.Recipe) == 0) {
+			continue
+		}
+		if f.Ingredient != "" && !slices.ContainsFunc(item.Recipe, func(i Ingredient) bool { return i.ItemID == f.Ingredient }) {
+			continue
+		}
+		if f.SellableOnly && item.IngredientOnly {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if int64(len(ids)) > f.Limit {
+		ids = ids[:f.Limit]
+	}
+
+	res := make([]*Item, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, cloneItem(s.stock[id]))
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) ApplyMovement(ctx context.Context, e *LedgerEntry) error {
+	defer s.lock(ctx)()
+
+	item, ok := s.stock[e.ItemID]
+	if !ok {
+		return ErrItemNotFound
+	}
+
+	level := item.Level(e.LocationID)
+	quantity := level.Quantity + e.QuantityDelta
+	reserved := level.Reserved + e.ReservedDelta
+	if reserved < 0 || quantity < reserved {
+		return ErrInsufficientStock
+	}
+	// expired lots can't be reserved
+	if e.ReservedDelta > 0 && quantity-level.Expired(e.At) < reserved {
+		return ErrInsufficientStock
+	}
+
+	lots, err := moveLots(level.Lots, e)
+	if err != nil {
+		return err
+	}
+
+	e.Quantity = quantity
+	e.Reserved = reserved
+	ledgerQuantity, ledgerReserved := s.sumLedger(e.ItemID, e.LocationID)
+	if err := checkBalances(e, ledgerQuantity, ledgerReserved); err != nil {
+		return err
+	}
+
+	if item.Levels == nil {
+		item.Levels = make(map[string]*StockLevel)
+	}
+	item.Levels[e.LocationID] = &StockLevel{Quantity: quantity, Reserved: reserved, Lots: lots}
+
+	entry := *e
+	s.ledger = append(s.ledger, &entry)
+
+	return nil
+}
+
+// moveLots applies the lot movements of e to a copy of lots, leaving out the
+// lots it empties.
+func moveLots(lots []*Lot, e *LedgerEntry) ([]*Lot, error) {
+	moved := make([]*Lot, 0, len(lots)+len(e.Lots))
+	for _, lot := range lots {
+		l := *lot
+		moved = append(moved, &l)
+	}
+
+	for _, m := range e.Lots {
+		if m.NewLot {
+			moved = append(moved, &Lot{ID: m.LotID, Quantity: m.QuantityDelta, ReceivedAt: e.At, ExpiresAt: m.ExpiresAt})
+			continue
+		}
+
+		i := slices.IndexFunc(moved, func(l *Lot) bool { return l.ID == m.LotID })
+		if i < 0 || moved[i].Quantity+m.QuantityDelta < 0 {
+			return nil, ErrInsufficientStock
+		}
+		moved[i].Quantity += m.QuantityDelta
+	}
+
+	moved = slices.DeleteFunc(moved, func(l *Lot) bool { return l.Quantity == 0 })
+	if len(moved) == 0 {
+		return nil, nil
+	}
+
+	return moved, nil
+}
+
+func (s *MemoryStore) ListLedger(ctx context.Context, itemID, location string, after primitive.ObjectID, limit int64) ([]*LedgerEntry, error) {
+	defer s.rlock(ctx)()
+
+	// entries are appended in order, so skip up to the last one seen
+	start := 0
+	if !after.IsZero() {
+		for i, e := range s.ledger {
+			if e.ID == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	res := make([]*LedgerEntry, 0)
+	for _, e := range s.ledger[start:] {
+		if int64(len(res)) == limit {
+			break
+		}
+		if e.ItemID == itemID && e.LocationID == location {
+			entry := *e
+			res = append(res, &entry)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) SumLedger(ctx context.Context, itemID, location string) (int32, int32, error) {
+	defer s.rlock(ctx)()
+
+	quantity, reserved := s.sumLedger(itemID, location)
+	return quantity, reserved, nil
+}
+
+// sumLedger adds up the entries of an item at a location, with the lock
+// held.
+func (s *MemoryStore) sumLedger(itemID, location string) (quantity, reserved int32) {
+	for _, e := range s.ledger {
+		if e.ItemID == itemID && e.LocationID == location {
+			quantity += e.QuantityDelta
+			reserved += e.ReservedDelta
+		}
+	}
+
+	return quantity, reserved
+}
+
+func (s *MemoryStore) SumConsumption(ctx context.Context, location string, since time.Time) (map[string]int32, error) {
+	defer s.rlock(ctx)()
+
+	consumed := make(map[string]int32)
+	for _, e := range s.ledger {
+		if e.Type == MovementCommit && e.LocationID == location && !e.At.Before(since) {
+			consumed[e.ItemID] -= e.QuantityDelta
+		}
+	}
+
+	return consumed, nil
+}
+
+func (s *MemoryStore) CreateReservation(ctx context.Context, r *Reservation) error {
+	defer s.lock(ctx)()
+
+	s.reservations[r.ID] = cloneReservation(r)
+	return nil
+}
+
+func (s *MemoryStore) GetReservation(ctx context.Context, id string) (*Reservation, error) {
+	defer s.rlock(ctx)()
+
+	r, ok := s.reservations[id]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+
+	return cloneReservation(r), nil
+}
+
+func (s *MemoryStore) UpdateReservationStatus(ctx context.Context, id, status string) error {
+	defer s.lock(ctx)()
+
+	r, ok := s.reservations[id]
+	if !ok {
+		return ErrReservationNotFound
+	}
+
+	r.Status = status
+	return nil
+}
+
+func (s *MemoryStore) ListExpiredReservations(ctx context.Context, now time.Time, limit int64) ([]*Reservation, error) {
+	defer s.rlock(ctx)()
+
+	var res []*Reservation
+	for _, r := range s.reservations {
+		if r.Status == ReservationHeld && r.ExpiresAt.Before(now) {
+			res = append(res, cloneReservation(r))
+		}
+	}
+
+	// oldest first, like the mongo store
+	sort.Slice(res, func(i, j int) bool { return res[i].ExpiresAt.Before(res[j].ExpiresAt) })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+func (s *MemoryStore) CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error {
+	defer s.lock(ctx)()
+
+	s.orders[po.ID] = clonePurchaseOrder(po)
+	return nil
+}
+
+func (s *MemoryStore) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error) {
+	defer s.rlock(ctx)()
+
+	po, ok := s.orders[id]
+	if !ok {
+		return nil, ErrPurchaseOrderNotFound
+	}
+
+	return clonePurchaseOrder(po), nil
+}
+
+func (s *MemoryStore) UpdatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error {
+	defer s.lock(ctx)()
+
+	current, ok := s.orders[po.ID]
+	if !ok {
+		return ErrPurchaseOrderNotFound
+	}
+
+	updated := clonePurchaseOrder(po)
+	current.Status = updated.Status
+	current.Lines = updated.Lines
+	current.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (s *MemoryStore) ListPurchaseOrders(ctx context.Context, f PurchaseOrderFilter) ([]*PurchaseOrder, error) {
+	defer s.rlock(ctx)()
+
+	ids := make([]string, 0, len(s.orders))
+	for id, po := range s.orders {
+		if id <= f.After || (f.Location != "" && po.LocationID != f.Location) {
+			continue
+		}
+		if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, po.Status) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if int64(len(ids)) > f.Limit {
+		ids = ids[:f.Limit]
+	}
+
+	res := make([]*PurchaseOrder, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, clonePurchaseOrder(s.orders[id]))
+	}
+
+	return res, nil
+}
+
+// WithTransaction holds the write lock while fn runs and puts back a
+// snapshot of the store if fn fails.
+func (s *MemoryStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) == s {
+		// already within a transaction
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stock := make(map[string]*Item, len(s.stock))
+	for id, item := range s.stock {
+		stock[id] = cloneItem(item)
+	}
+	reservations := make(map[string]*Reservation, len(s.reservations))
+	for id, r := range s.reservations {
+		reservations[id] = cloneReservation(r)
+	}
+	orders := make(map[string]*PurchaseOrder, len(s.orders))
+	for id, po := range s.orders {
+		orders[id] = clonePurchaseOrder(po)
+	}
+	// entries are never changed, dropping the new ones is enough
+	ledgerLen := len(s.ledger)
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, s)); err != nil {
+		s.stock = stock
+		s.reservations = reservations
+		s.orders = orders
+		s.ledger = s.ledger[:ledgerLen]
+		return err
+	}
+
+	return nil
+}
+
+// cloneItem copies an item along with its levels and their lots, which
+// ApplyMovement changes in place.
+func cloneItem(item *Item) *Item {
+	c := *item
+	c.Recipe = slices.Clone(item.Recipe)
+	c.Levels = make(map[string]*StockLevel, len(item.Levels))
+	for location, level := range item.Levels {
+		l := *level
+		l.Lots = make([]*Lot, 0, len(level.Lots))
+		for _, lot := range level.Lots {
+			lotCopy := *lot
+			l.Lots = append(l.Lots, &lotCopy)
+		}
+		c.Levels[location] = &l
+	}
+
+	return &c
+}
+
+func cloneReservation(r *Reservation) *Reservation {
+	c := *r
+	c.Items = make([]*ReservedItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		i := *item
+		c.Items = append(c.Items, &i)
+	}
+	c.Holds = make([]*ReservedItem, 0, len(r.Holds))
+	for _, item := range r.Holds {
+		i := *item
+		c.Holds = append(c.Holds, &i)
+	}
+
+	return &c
+}
+
+func clonePurchaseOrder(po *PurchaseOrder) *PurchaseOrder {
+	c := *po
+	c.Lines = make([]*PurchaseOrderLine, 0, len(po.Lines))
+	for _, line := range po.Lines {
+		l := *line
+		c.Lines = append(c.Lines, &l)
+	}
+
+	return &c
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ingredient is a stock item used up, Quantity at a time, to make another
+// item. Ingredients are shared between items, so a burger and a cheese
+// burger run out together when the buns do.
+type Ingredient struct {
+	ItemID   string `bson:"itemID" json:"itemID"`
+	Quantity int32  `bson:"quantity" json:"quantity"`
+}
+
+func (i Ingredient) ToProto() *pb.Ingredient {
+	return &pb.Ingredient{ItemID: i.ItemID, Quantity: i.Quantity}
+}
+
+func recipeFromProto(p []*pb.Ingredient) []Ingredient {
+	if len(p) == 0 {
+		return nil
+	}
+
+	recipe := make([]Ingredient, 0, len(p))
+	for _, ingredient := range p {
+		recipe = append(recipe, Ingredient{ItemID: ingredient.ItemID, Quantity: ingredient.Quantity})
+	}
+
+	return recipe
+}
+
+// catalog holds the items an operation works on along with their
+// ingredients, by ID, and the location whose stock it works with.
+type catalog struct {
+	location string
+	items    map[string]*Item
+}
+
+func newCatalog(location string, items []*Item) *catalog {
+	c := &catalog{location, make(map[string]*Item, len(items))}
+	for _, item := range items {
+		c.items[item.ID] = item
+	}
+
+	return c
+}
+
+// loadCatalog gets the given items and the ingredients of their recipes.
+// Unknown IDs are left out.
+func (s *Service) loadCatalog(ctx context.Context, location string, ids []string) (*catalog, error) {
+	items, err := s.store.GetItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCatalog(location, items)
+	return c, s.addIngredients(ctx, c, items)
+}
+
+// addIngredients adds the ingredients of the given items to c.
+func (s *Service) addIngredients(ctx context.Context, c *catalog, items []*Item) error {
+	var ids []string
+	for _, item := range items {
+		for _, ingredient := range item.Recipe {
+			if _, ok := c.items[ingredient.ItemID]; !ok {
+				ids = append(ids, ingredient.ItemID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ingredients, err := s.store.GetItems(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, item := range ingredients {
+		c.items[item.ID] = item
+	}
+
+	return nil
+}
+
+// stockLines lists the stock the given items draw from: items with a recipe
+// draw from their ingredients, any other item from itself. Lines drawing
+// from the same stock item are merged, in the order they first appear.
+func (c *catalog) stockLines(items []*ReservedItem) []*ReservedItem {
+	lines := make([]*ReservedItem, 0, len(items))
+	byID := make(map[string]*ReservedItem, len(items))
+
+	add := func(id string, quantity int32) {
+		if line, ok := byID[id]; ok {
+			line.Quantity += quantity
+			return
+		}
+
+		line := &ReservedItem{ID: id, Quantity: quantity}
+		byID[id] = line
+		lines = append(lines, line)
+	}
+
+	for _, item := range items {
+		stockItem, ok := c.items[item.ID]
+		if !ok || len(stockItem.Recipe) == 0 {
+			add(item.ID, item.Quantity)
+			continue
+		}
+
+		for _, ingredient := range stockItem.Recipe {
+			add(ingredient.ItemID, ingredient.Quantity*item.Quantity)
+		}
+	}
+
+	return lines
+}
+
+// makeable tells how many units of an item the stock at the location
+// allows, measuring every stock item it draws from with measure.
+func (c *catalog) makeable(item *Item, measure func(StockLevel) int32) int32 {
+	if len(item.Recipe) == 0 {
+		return max(measure(item.Level(c.location)), 0)
+	}
+
+	n := int32(math.MaxInt32)
+	for _, ingredient := range item.Recipe {
+		stockItem, ok := c.items[ingredient.ItemID]
+		if !ok {
+			return 0
+		}
+		n = min(n, max(measure(stockItem.Level(c.location)), 0)/ingredient.Quantity)
+	}
+
+	return n
+}
+
+// covers tells whether the stock the item draws from has enough available
+// at the location for the demand on it, by stock item ID.
+func (c *catalog) covers(item *Item, demand map[string]int32) bool {
+	for _, line := range c.stockLines([]*ReservedItem{{ID: item.ID, Quantity: 1}}) {
+		stockItem, ok := c.items[line.ID]
+		if !ok || stockItem.Level(c.location).Available() < demand[line.ID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// onHand measures the stock at a location that can still be sold, including
+// what is reserved.
+func onHand(l StockLevel) int32 {
+	return l.Quantity - l.Expired(time.Now())
+}
+
+// validateRecipe checks the recipe of an item against the catalog. Recipes
+// are one level deep: ingredients are made from nothing, and an item that
+// is an ingredient of another one can't get a recipe itself. Items with a
+// recipe don't hold stock of their own.
+func (s *Service) validateRecipe(ctx context.Context, item *Item) error {
+	if len(item.Recipe) == 0 {
+		return nil
+	}
+
+	for location, level := range item.Levels {
+		if level.Quantity != 0 || level.Reserved != 0 {
+			return status.Errorf(codes.FailedPrecondition, "item %s holds stock at %s, it can't be made from ingredients", item.ID, location)
+		}
+	}
+
+	ids := make([]string, 0, len(item.Recipe))
+	for _, ingredient := range item.Recipe {
+		ids = append(ids, ingredient.ItemID)
+	}
+
+	ingredients, err := s.store.GetItems(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]*Item, len(ingredients))
+	for _, ingredient := range ingredients {
+		found[ingredient.ID] = ingredient
+	}
+
+	for _, id := range ids {
+		ingredient, ok := found[id]
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "ingredient %s not found", id)
+		}
+		if len(ingredient.Recipe) > 0 {
+			return status.Errorf(codes.InvalidArgument, "ingredient %s is itself made from ingredients", id)
+		}
+	}
+
+	usedIn, err := s.store.ListItems(ctx, ListItemsFilter{Ingredient: item.ID, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(usedIn) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "item %s is an ingredient of %s, it can't be made from ingredients", item.ID, usedIn[0].ID)
+	}
+
+	return nil
+}
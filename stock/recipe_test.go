@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+)
+
+func recipeCatalog() *catalog {
+	level := func(quantity int32) map[string]*StockLevel {
+		return map[string]*StockLevel{common.DefaultLocation: {Quantity: quantity}}
+	}
+
+	return newCatalog(common.DefaultLocation, []*Item{
+		{ID: "bun", Levels: level(10), IngredientOnly: true},
+		{ID: "patty", Levels: level(9), IngredientOnly: true},
+		{ID: "cheese", Levels: level(4), IngredientOnly: true},
+		{ID: "chips", Levels: level(5)},
+		{ID: "burger", Recipe: []Ingredient{{ItemID: "bun", Quantity: 1}, {ItemID: "patty", Quantity: 1}}},
+		{ID: "double", Recipe: []Ingredient{{ItemID: "bun", Quantity: 1}, {ItemID: "patty", Quantity: 2}, {ItemID: "cheese", Quantity: 1}}},
+	})
+}
+
+func TestStockLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []*ReservedItem
+		want  []ReservedItem
+	}{
+		{
+			name:  "item without a recipe",
+			items: []*ReservedItem{{ID: "chips", Quantity: 2}},
+			want:  []ReservedItem{{ID: "chips", Quantity: 2}},
+		},
+		{
+			name:  "recipe scaled by the quantity",
+			items: []*ReservedItem{{ID: "double", Quantity: 3}},
+			want:  []ReservedItem{{ID: "bun", Quantity: 3}, {ID: "patty", Quantity: 6}, {ID: "cheese", Quantity: 3}},
+		},
+		{
+			name:  "shared ingredients merged",
+			items: []*ReservedItem{{ID: "burger", Quantity: 2}, {ID: "chips", Quantity: 1}, {ID: "double", Quantity: 1}},
+			want:  []ReservedItem{{ID: "bun", Quantity: 3}, {ID: "patty", Quantity: 4}, {ID: "chips", Quantity: 1}, {ID: "cheese", Quantity: 1}},
+		},
+		{
+			name:  "unknown item kept as is",
+			items: []*ReservedItem{{ID: "soda", Quantity: 1}},
+			want:  []ReservedItem{{ID: "soda", Quantity: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recipeCatalog().stockLines(tt.items)
+			if !slices.EqualFunc(got, tt.want, func(a *ReservedItem, b ReservedItem) bool { return *a == b }) {
+				lines := make([]ReservedItem, 0, len(got))
+				for _, line := range got {
+					lines = append(lines, *line)
+				}
+				t.Errorf("stockLines() = %v, want %v", lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeable(t *testing.T) {
+	tests := []struct {
+		item string
+		want int32
+	}{
+		{item: "chips", want: 5},
+		// 9 patties make 9 burgers, the buns would make 10
+		{item: "burger", want: 9},
+		// 9 patties make 4 doubles, and so does the cheese
+		{item: "double", want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.item, func(t *testing.T) {
+			c := recipeCatalog()
+			if got := c.makeable(c.items[tt.item], onHand); got != tt.want {
+				t.Errorf("makeable(%s) = %d, want %d", tt.item, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckIfItemAreInStockSharesIngredients(t *testing.T) {
+	ingredient := func(id string, quantity int32) *Item {
+		item := stockedItem(id, quantity)
+		item.IngredientOnly = true
+		return item
+	}
+	made := func(id string, recipe ...Ingredient) *Item {
+		return &Item{ID: id, Name: id, UnitAmount: 500, Currency: "usd", Recipe: recipe}
+	}
+
+	tests := []struct {
+		name  string
+		items []*pb.ItemsWithQuantity
+		want  map[string]string
+	}{
+		{
+			name:  "enough for both",
+			items: []*pb.ItemsWithQuantity{{ID: "burger", Quantity: 2}, {ID: "cheeseburger", Quantity: 2}},
+			want:  map[string]string{"burger": common.AvailabilityOK, "cheeseburger": common.AvailabilityOK},
+		},
+		{
+			// each fits in the buns on its own, not together
+			name:  "buns run out for both",
+			items: []*pb.ItemsWithQuantity{{ID: "burger", Quantity: 3}, {ID: "cheeseburger", Quantity: 2}},
+			want:  map[string]string{"burger": common.AvailabilityInsufficient, "cheeseburger": common.AvailabilityInsufficient},
+		},
+		{
+			name:  "ingredient on its own",
+			items: []*pb.ItemsWithQuantity{{ID: "bun", Quantity: 1}},
+			want:  map[string]string{"bun": common.AvailabilityNotForSale},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t,
+				ingredient("bun", 4),
+				ingredient("patty", 10),
+				made("burger", Ingredient{ItemID: "bun", Quantity: 1}, Ingredient{ItemID: "patty", Quantity: 1}),
+				made("cheeseburger", Ingredient{ItemID: "bun", Quantity: 1}, Ingredient{ItemID: "patty", Quantity: 1}),
+			)
+
+			_, _, availability, err := svc.CheckIfItemAreInStock(context.Background(), "", tt.items)
+			if err != nil {
+				t.Fatalf("CheckIfItemAreInStock: %v", err)
+			}
+			if len(availability) != len(tt.want) {
+				t.Fatalf("got the availability of %d items, want %d", len(availability), len(tt.want))
+			}
+
+			for _, a := range availability {
+				if a.Status != tt.want[a.ItemID] {
+					t.Errorf("%s is %q, want %q", a.ItemID, a.Status, tt.want[a.ItemID])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+
+	DefaultConsumptionWindowDays = 14
+	DefaultCoverDays             = 7
+	maxReplenishmentDays         = 365
+
+	maxSupplierLength = 200
+	maxNoteLength     = 1000
+)
+
+// PurchaseOrder orders items from a supplier for delivery at a location.
+// Deliveries are received line by line, possibly over several receipts.
+type PurchaseOrder struct {
+	ID         string               `bson:"_id"`
+	Supplier   string               `bson:"supplier"`
+	LocationID string               `bson:"locationID"`
+	Status     string               `bson:"status"`
+	Lines      []*PurchaseOrderLine `bson:"lines"`
+	Note       string               `bson:"note,omitempty"`
+	CreatedAt  time.Time            `bson:"createdAt"`
+	UpdatedAt  time.Time            `bson:"updatedAt"`
+}
+
+type PurchaseOrderLine struct {
+	ItemID   string `bson:"itemID"`
+	Quantity int32  `bson:"quantity"`
+	Received int32  `bson:"received"`
+}
+
+// outstanding is what is still to be delivered.
+func (l *PurchaseOrderLine) outstanding() int32 {
+	return max(l.Quantity-l.Received, 0)
+}
+
+// pending tells whether deliveries are still expected.
+func (po *PurchaseOrder) pending() bool {
+	return po.Status == PurchaseOrderOpen || po.Status == PurchaseOrderPartiallyReceived
+}
+
+func (po *PurchaseOrder) line(itemID string) *PurchaseOrderLine {
+	for _, l := range po.Lines {
+		if l.ItemID == itemID {
+			return l
+		}
+	}
+
+	return nil
+}
+
+func (po *PurchaseOrder) ToProto() *pb.PurchaseOrder {
+	lines := make([]*pb.PurchaseOrderLine, 0, len(po.Lines))
+	for _, l := range po.Lines {
+		lines = append(lines, &pb.PurchaseOrderLine{ItemID: l.ItemID, Quantity: l.Quantity, Received: l.Received})
+	}
+
+	return &pb.PurchaseOrder{
+		ID:         po.ID,
+		Supplier:   po.Supplier,
+		LocationID: po.LocationID,
+		Status:     po.Status,
+		Lines:      lines,
+		Note:       po.Note,
+		CreatedAt:  po.CreatedAt.Unix(),
+		UpdatedAt:  po.UpdatedAt.Unix(),
+	}
+}
+
+// PurchaseOrderFilter narrows down purchase orders, ordered by ID. After,
+// when set, is the ID of the last purchase order of the previous page.
+type PurchaseOrderFilter struct {
+	// Statuses keeps the purchase orders in any of them, all when empty
+	Statuses []string
+	Location string
+	After    string
+	Limit    int64
+}
+
+// ReorderSuggestions lists the items of a location due for reordering.
+type ReorderSuggestions struct {
+	LocationID  string
+	WindowDays  int
+	CoverDays   int
+	Suggestions []*ReorderSuggestion
+}
+
+type ReorderSuggestion struct {
+	Item      *Item
+	Available int32
+	OnOrder   int32
+	Consumed  int32
+	Daily     float64
+	Quantity  int32
+}
+
+func (r *ReorderSuggestions) ToProto() *pb.ReorderSuggestions {
+	p := &pb.ReorderSuggestions{
+		LocationID:  r.LocationID,
+		WindowDays:  int32(r.WindowDays),
+		CoverDays:   int32(r.CoverDays),
+		Suggestions: make([]*pb.ReorderSuggestion, 0, len(r.Suggestions)),
+	}
+	for _, s := range r.Suggestions {
+		p.Suggestions = append(p.Suggestions, &pb.ReorderSuggestion{
+			ItemID:           s.Item.ID,
+			Name:             s.Item.Name,
+			Available:        s.Available,
+			OnOrder:          s.OnOrder,
+			ReorderPoint:     s.Item.ReorderPoint,
+			Consumed:         s.Consumed,
+			DailyConsumption: s.Daily,
+			Quantity:         s.Quantity,
+		})
+	}
+
+	return p
+}
+
+// ReorderSuggestions works out what a location should order. Consumption is
+// what orders committed over the window, and an item is due once its stock
+// position, available plus on order, drops to its reorder point or below
+// what the cover period consumes. The suggested quantity covers the period
+// on top of the reorder point. Items made from ingredients are replenished
+// through their ingredients.
+func (s *Service) ReorderSuggestions(ctx context.Context, p *pb.ReorderSuggestionsRequest) (*ReorderSuggestions, error) {
+	location, err := validateLocation(p.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	windowDays, err := replenishmentDays("window", p.WindowDays, DefaultConsumptionWindowDays)
+	if err != nil {
+		return nil, err
+	}
+	coverDays, err := replenishmentDays("cover", p.CoverDays, DefaultCoverDays)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -windowDays)
+	consumed, err := s.store.SumConsumption(ctx, location, since)
+	if err != nil {
+		return nil, err
+	}
+
+	onOrder, err := s.onOrder(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReorderSuggestions{LocationID: location, WindowDays: windowDays, CoverDays: coverDays}
+
+	var after string
+	for {
+		items, err := s.store.ListItems(ctx, ListItemsFilter{After: after, Limit: MaxPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if len(item.Recipe) > 0 {
+				continue
+			}
+
+			daily := float64(consumed[item.ID]) / float64(windowDays)
+			cover := int32(math.Ceil(daily * float64(coverDays)))
+			available := max(item.Level(location).Available(), 0)
+			position := available + onOrder[item.ID]
+
+			due := position < cover || (item.ReorderPoint > 0 && position <= item.ReorderPoint)
+			if !due {
+				continue
+			}
+
+			res.Suggestions = append(res.Suggestions, &ReorderSuggestion{
+				Item:      item,
+				Available: available,
+				OnOrder:   onOrder[item.ID],
+				Consumed:  consumed[item.ID],
+				Daily:     daily,
+				Quantity:  max(item.ReorderPoint+cover-position, 1),
+			})
+		}
+
+		if len(items) < MaxPageSize {
+			return res, nil
+		}
+		after = items[len(items)-1].ID
+	}
+}
+
+func replenishmentDays(name string, days int32, fallback int) (int, error) {
+	switch {
+	case days == 0:
+		return fallback, nil
+	case days < 0 || days > maxReplenishmentDays:
+		return 0, status.Errorf(codes.InvalidArgument, "%s days must be between 1 and %d", name, maxReplenishmentDays)
+	default:
+		return int(days), nil
+	}
+}
+
+// onOrder adds up what the pending purchase orders of a location still have
+// to deliver, by item ID.
+func (s *Service) onOrder(ctx context.Context, location string) (map[string]int32, error) {
+	onOrder := make(map[string]int32)
+
+	f := PurchaseOrderFilter{
+		Statuses: []string{PurchaseOrderOpen, PurchaseOrderPartiallyReceived},
+		Location: location,
+		Limit:    MaxPageSize,
+	}
+	for {
+		orders, err := s.store.ListPurchaseOrders(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, po := range orders {
+			for _, l := range po.Lines {
+				onOrder[l.ItemID] += l.outstanding()
+			}
+		}
+
+		if len(orders) < MaxPageSize {
+			return onOrder, nil
+		}
+		f.After = orders[len(orders)-1].ID
+	}
+}
+
+func (s *Service) CreatePurchaseOrder(ctx context.Context, p *pb.CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	location, err := validateLocation(p.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier := strings.TrimSpace(p.Supplier)
+	if supplier == "" {
+		return nil, status.Error(codes.InvalidArgument, "supplier is required")
+	}
+	if len(supplier) > maxSupplierLength {
+		return nil, status.Errorf(codes.InvalidArgument, "supplier is longer than %d characters", maxSupplierLength)
+	}
+	if len(p.Note) > maxNoteLength {
+		return nil, status.Errorf(codes.InvalidArgument, "note is longer than %d characters", maxNoteLength)
+	}
+	if len(p.Lines) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a purchase order needs at least one line")
+	}
+
+	now := time.Now()
+	po := &PurchaseOrder{
+		ID:         primitive.NewObjectID().Hex(),
+		Supplier:   supplier,
+		LocationID: location,
+		Status:     PurchaseOrderOpen,
+		Note:       strings.TrimSpace(p.Note),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	ids := make([]string, 0, len(p.Lines))
+	for _, l := range p.Lines {
+		if l.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity %d for item %s", l.Quantity, l.ItemID)
+		}
+		if po.line(l.ItemID) != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "item %s is listed twice", l.ItemID)
+		}
+		po.Lines = append(po.Lines, &PurchaseOrderLine{ItemID: l.ItemID, Quantity: l.Quantity})
+		ids = append(ids, l.ItemID)
+	}
+
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		items, err := s.store.GetItems(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		found := make(map[string]*Item, len(items))
+		for _, item := range items {
+			found[item.ID] = item
+		}
+		for _, id := range ids {
+			item, ok := found[id]
+			if !ok {
+				return status.Errorf(codes.NotFound, "item %s not found", id)
+			}
+			if len(item.Recipe) > 0 {
+				return status.Errorf(codes.FailedPrecondition, "item %s is made from ingredients, order those instead", id)
+			}
+		}
+
+		return s.store.CreatePurchaseOrder(ctx, po)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
+
+func (s *Service) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error) {
+	po, err := s.store.GetPurchaseOrder(ctx, id)
+	if errors.Is(err, ErrPurchaseOrderNotFound) {
+		return nil, status.Errorf(codes.NotFound, "purchase order %s not found", id)
+	}
+
+	return po, err
+}
+
+// ListPurchaseOrders pages through the purchase orders, oldest first.
+func (s *Service) ListPurchaseOrders(ctx context.Context, p *pb.ListPurchaseOrdersRequest) ([]*PurchaseOrder, string, error) {
+	f := PurchaseOrderFilter{}
+
+	switch p.Status {
+	case "":
+	case PurchaseOrderOpen, PurchaseOrderPartiallyReceived, PurchaseOrderReceived, PurchaseOrderCancelled:
+		f.Statuses = []string{p.Status}
+	default:
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid status %q", p.Status)
+	}
+
+	if p.LocationID != "" {
+		location, err := validateLocation(p.LocationID)
+		if err != nil {
+			return nil, "", err
+		}
+		f.Location = location
+	}
+
+	pageSize := int(p.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	if p.PageToken != "" {
+		b, err := base64.RawURLEncoding.DecodeString(p.PageToken)
+		if err != nil {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		f.After = string(b)
+	}
+
+	// fetch one extra purchase order to know whether there is a next page
+	f.Limit = int64(pageSize + 1)
+	orders, err := s.store.ListPurchaseOrders(ctx, f)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		next = base64.RawURLEncoding.EncodeToString([]byte(orders[pageSize-1].ID))
+	}
+
+	return orders, next, nil
+}
+
+// ReceivePurchaseOrder restocks what a delivery brought, the way
+// AdjustQuantity restocks any other delivery, and records it against the
+// lines of the purchase order. Without lines the whole outstanding
+// quantity was delivered. Deliveries can't exceed what is outstanding.
+func (s *Service) ReceivePurchaseOrder(ctx context.Context, p *pb.ReceivePurchaseOrderRequest) (*PurchaseOrder, error) {
+	for _, l := range p.Lines {
+		if l.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity %d for item %s", l.Quantity, l.ItemID)
+		}
+	}
+
+	var (
+		po      *PurchaseOrder
+		entries []*LedgerEntry
+	)
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		// the store may run fn again on transient errors
+		entries = nil
+
+		var err error
+		po, err = s.GetPurchaseOrder(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		if !po.pending() {
+			return status.Errorf(codes.FailedPrecondition, "purchase order %s is %s", po.ID, po.Status)
+		}
+
+		delivered := p.Lines
+		if len(delivered) == 0 {
+			for _, l := range po.Lines {
+				if l.outstanding() > 0 {
+					delivered = append(delivered, &pb.ReceivedLine{ItemID: l.ItemID, Quantity: l.outstanding()})
+				}
+			}
+		}
+
+		for _, d := range delivered {
+			line := po.line(d.ItemID)
+			if line == nil {
+				return status.Errorf(codes.InvalidArgument, "item %s is not on purchase order %s", d.ItemID, po.ID)
+			}
+			if d.Quantity > line.outstanding() {
+				return status.Errorf(codes.FailedPrecondition, "%d units of item %s are outstanding, %d were delivered", line.outstanding(), d.ItemID, d.Quantity)
+			}
+
+			_, e, err := s.adjustQuantity(ctx, &pb.AdjustQuantityRequest{
+				ItemID:     d.ItemID,
+				Delta:      d.Quantity,
+				Reason:     fmt.Sprintf("purchase order %s from %s", po.ID, po.Supplier),
+				Restock:    true,
+				LocationID: po.LocationID,
+				LotID:      d.LotID,
+				ExpiresAt:  d.ExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+			line.Received += d.Quantity
+			entries = append(entries, e)
+		}
+
+		po.Status = PurchaseOrderReceived
+		for _, l := range po.Lines {
+			if l.outstanding() > 0 {
+				po.Status = PurchaseOrderPartiallyReceived
+			}
+		}
+		po.UpdatedAt = time.Now()
+
+		return s.store.UpdatePurchaseOrder(ctx, po)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyAlerts(ctx, entries)
+	return po, nil
+}
+
+// CancelPurchaseOrder gives up on what a purchase order still has to
+// deliver, what it delivered stays in stock.
+func (s *Service) CancelPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error) {
+	var po *PurchaseOrder
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		po, err = s.GetPurchaseOrder(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case po.Status == PurchaseOrderCancelled:
+			return nil
+		case !po.pending():
+			return status.Errorf(codes.FailedPrecondition, "purchase order %s is %s", id, po.Status)
+		}
+
+		po.Status = PurchaseOrderCancelled
+		po.UpdatedAt = time.Now()
+		return s.store.UpdatePurchaseOrder(ctx, po)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return po, nil
+}
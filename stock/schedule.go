@@ -0,0 +1,176 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/rikughi/commons/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	scheduleTimeLayout = "15:04"
+	scheduleDateLayout = "2006-01-02"
+)
+
+// weekdays are the day names windows use, indexed by time.Weekday.
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Schedule restricts when an item can be ordered: on the dates within any
+// of Dates, at the times within any of Windows. Both are read in TimeZone.
+type Schedule struct {
+	// TimeZone is an IANA time zone name, UTC when empty
+	TimeZone string               `bson:"timeZone,omitempty" json:"timeZone,omitempty"`
+	Windows  []AvailabilityWindow `bson:"windows,omitempty" json:"windows,omitempty"`
+	Dates    []DateRange          `bson:"dates,omitempty" json:"dates,omitempty"`
+}
+
+// AvailabilityWindow is a time range on some days of the week. A window
+// ending before it starts runs past midnight into the next day.
+type AvailabilityWindow struct {
+	// Days are lower case three letter day names, every day when empty
+	Days  []string `bson:"days,omitempty" json:"days,omitempty"`
+	Start string   `bson:"start" json:"start"`
+	End   string   `bson:"end" json:"end"`
+}
+
+// DateRange includes both its From and Until dates.
+type DateRange struct {
+	From  string `bson:"from" json:"from"`
+	Until string `bson:"until" json:"until"`
+}
+
+// OpenAt tells whether the schedule allows ordering at t. A nil schedule
+// always does.
+func (s *Schedule) OpenAt(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		// validated when the item was saved, the zone database changed since
+		return false
+	}
+	local := t.In(loc)
+
+	if len(s.Dates) > 0 && !slices.ContainsFunc(s.Dates, func(d DateRange) bool { return d.contains(local) }) {
+		return false
+	}
+
+	return len(s.Windows) == 0 || slices.ContainsFunc(s.Windows, func(w AvailabilityWindow) bool { return w.contains(local) })
+}
+
+func (d DateRange) contains(t time.Time) bool {
+	date := t.Format(scheduleDateLayout)
+	return d.From <= date && date <= d.Until
+}
+
+func (w AvailabilityWindow) contains(t time.Time) bool {
+	start, _ := minuteOfDay(w.Start)
+	end, _ := minuteOfDay(w.End)
+	now := t.Hour()*60 + t.Minute()
+
+	if start < end {
+		return w.onDay(t.Weekday()) && start <= now && now < end
+	}
+
+	// the window started the day before when it is past midnight
+	return (w.onDay(t.Weekday()) && now >= start) || (w.onDay((t.Weekday()+6)%7) && now < end)
+}
+
+func (w AvailabilityWindow) onDay(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, weekdays[day])
+}
+
+func minuteOfDay(hhmm string) (int, error) {
+	t, err := time.Parse(scheduleTimeLayout, hhmm)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateSchedule checks a schedule before it is saved, lower casing its
+// day names.
+func validateSchedule(s *Schedule) error {
+	if s == nil {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unknown time zone %q", s.TimeZone)
+	}
+
+	for _, w := range s.Windows {
+		for i, day := range w.Days {
+			w.Days[i] = strings.ToLower(day)
+			if !slices.Contains(weekdays, w.Days[i]) {
+				return status.Errorf(codes.InvalidArgument, "invalid day %q, use %s", day, strings.Join(weekdays, ", "))
+			}
+		}
+
+		start, err := minuteOfDay(w.Start)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid window start %q, use HH:MM", w.Start)
+		}
+		end, err := minuteOfDay(w.End)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid window end %q, use HH:MM", w.End)
+		}
+		if start == end {
+			return status.Errorf(codes.InvalidArgument, "window %s-%s is empty", w.Start, w.End)
+		}
+	}
+
+	for _, d := range s.Dates {
+		from, err := time.Parse(scheduleDateLayout, d.From)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid date %q, use YYYY-MM-DD", d.From)
+		}
+		until, err := time.Parse(scheduleDateLayout, d.Until)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid date %q, use YYYY-MM-DD", d.Until)
+		}
+		if until.Before(from) {
+			return status.Errorf(codes.InvalidArgument, "date range %s to %s ends before it starts", d.From, d.Until)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schedule) ToProto() *pb.Schedule {
+	if s == nil {
+		return nil
+	}
+
+	p := &pb.Schedule{TimeZone: s.TimeZone}
+	for _, w := range s.Windows {
+		p.Windows = append(p.Windows, &pb.AvailabilityWindow{Days: w.Days, Start: w.Start, End: w.End})
+	}
+	for _, d := range s.Dates {
+		p.Dates = append(p.Dates, &pb.DateRange{From: d.From, Until: d.Until})
+	}
+
+	return p
+}
+
+func scheduleFromProto(p *pb.Schedule) *Schedule {
+	if p == nil {
+		return nil
+	}
+
+	s := &Schedule{TimeZone: p.TimeZone}
+	for _, w := range p.Windows {
+		s.Windows = append(s.Windows, AvailabilityWindow{Days: w.Days, Start: w.Start, End: w.End})
+	}
+	for _, d := range p.Dates {
+		s.Dates = append(s.Dates, DateRange{From: d.From, Until: d.Until})
+	}
+
+	return s
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleOpenAt(t *testing.T) {
+	// 2026-05-01 is a Friday
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 5, day, hour, min, 0, 0, time.UTC)
+	}
+	lateNight := &Schedule{Windows: []AvailabilityWindow{{Days: []string{"fri"}, Start: "22:00", End: "02:00"}}}
+
+	tests := []struct {
+		name     string
+		schedule *Schedule
+		t        time.Time
+		want     bool
+	}{
+		{name: "no schedule", schedule: nil, t: at(1, 3, 0), want: true},
+		{name: "before the window", schedule: lateNight, t: at(1, 21, 59), want: false},
+		{name: "window start", schedule: lateNight, t: at(1, 22, 0), want: true},
+		{name: "before midnight", schedule: lateNight, t: at(1, 23, 59), want: true},
+		{name: "past midnight on the next day", schedule: lateNight, t: at(2, 0, 30), want: true},
+		{name: "window end", schedule: lateNight, t: at(2, 2, 0), want: false},
+		{name: "past midnight into the window day", schedule: lateNight, t: at(1, 1, 0), want: false},
+		{name: "evening of the next day", schedule: lateNight, t: at(2, 23, 0), want: false},
+		{
+			name: "every day past midnight",
+			schedule: &Schedule{Windows: []AvailabilityWindow{
+				{Start: "22:00", End: "02:00"},
+			}},
+			t:    at(4, 1, 59),
+			want: true,
+		},
+		{
+			// 23:30 UTC is 00:30 in Lisbon in summer
+			name: "read in the time zone",
+			schedule: &Schedule{TimeZone: "Europe/Lisbon", Windows: []AvailabilityWindow{
+				{Days: []string{"sat"}, Start: "00:00", End: "01:00"},
+			}},
+			t:    at(1, 23, 30),
+			want: true,
+		},
+		{
+			name: "past midnight outside of the dates",
+			schedule: &Schedule{
+				Windows: []AvailabilityWindow{{Start: "22:00", End: "02:00"}},
+				Dates:   []DateRange{{From: "2026-05-01", Until: "2026-05-01"}},
+			},
+			t:    at(2, 0, 30),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.OpenAt(tt.t); got != tt.want {
+				t.Errorf("OpenAt(%s) = %v, want %v", tt.t.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
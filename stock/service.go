@@ -2,56 +2,422 @@ package main
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Service struct {
 	store StockStore
+	// reservationTTL applies to reservations that don't ask for a TTL
+	reservationTTL time.Duration
+	alerts         *stockAlerts
 }
 
-func NewService(store StockStore) *Service {
-	return &Service{store}
+func NewService(store StockStore, reservationTTL time.Duration, alerts *stockAlerts) *Service {
+	return &Service{store, reservationTTL, alerts}
 }
 
-func (s *Service) CheckIfItemAreInStock(ctx context.Context, p []*pb.ItemsWithQuantity) (bool, []*pb.Item, error) {
-	itemIDs := make([]string, 0)
-	for _, item := range p {
+// CheckIfItemAreInStock prices the requested items and tells for each of
+// them whether the location can supply it, leaving out what is held for
+// other orders. Items made from ingredients are checked against the
+// ingredients, adding up what all the requested items need of them.
+func (s *Service) CheckIfItemAreInStock(ctx context.Context, location string, p []*pb.ItemsWithQuantity) (bool, []*pb.Item, []*pb.ItemAvailability, error) {
+	location, err := validateLocation(location)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	requested := mergeRequestedItems(p)
+
+	itemIDs := make([]string, 0, len(requested))
+	lines := make([]*ReservedItem, 0, len(requested))
+	for _, item := range requested {
 		itemIDs = append(itemIDs, item.ID)
+		lines = append(lines, &ReservedItem{ID: item.ID, Quantity: item.Quantity})
 	}
 
-	itemsInStock, err := s.store.GetItems(ctx, itemIDs)
+	stock, err := s.loadCatalog(ctx, location, itemIDs)
 	if err != nil {
-		return false, nil, err
+		return false, nil, nil, err
 	}
 
-	// Check if all items are in stock
-	for _, stockItem := range itemsInStock {
-		for _, reqItem := range p {
-			if stockItem.ID == reqItem.ID && stockItem.Quantity < reqItem.Quantity {
-				return false, itemsInStock, nil
+	demand := make(map[string]int32)
+	for _, line := range stock.stockLines(lines) {
+		demand[line.ID] = line.Quantity
+	}
+
+	now := time.Now()
+	inStock := true
+	items := make([]*pb.Item, 0, len(requested))
+	availability := make([]*pb.ItemAvailability, 0, len(requested))
+
+	for _, reqItem := range requested {
+		a := &pb.ItemAvailability{
+			ItemID:    reqItem.ID,
+			Status:    common.AvailabilityOK,
+			Requested: reqItem.Quantity,
+		}
+		availability = append(availability, a)
+
+		stockItem, ok := stock.items[reqItem.ID]
+		if !ok {
+			a.Status = common.AvailabilityUnknown
+			inStock = false
+			continue
+		}
+
+		a.Available = stock.makeable(stockItem, StockLevel.Available)
+		switch {
+		case stockItem.IngredientOnly:
+			a.Status = common.AvailabilityNotForSale
+			inStock = false
+		case !stockItem.Schedule.OpenAt(now):
+			a.Status = common.AvailabilityUnavailableNow
+			inStock = false
+		case a.Available < reqItem.Quantity || !stock.covers(stockItem, demand):
+			a.Status = common.AvailabilityInsufficient
+			inStock = false
+		}
+
+		// create items with authoritative prices from stock
+		items = append(items, &pb.Item{
+			ID:         stockItem.ID,
+			Name:       stockItem.Name,
+			PriceID:    stockItem.PriceID,
+			Quantity:   reqItem.Quantity,
+			UnitAmount: stockItem.UnitAmount,
+			Currency:   stockItem.Currency,
+		})
+	}
+
+	return inStock, items, availability, nil
+}
+
+// mergeRequestedItems adds up the quantities of items requested more than
+// once, keeping the order they were first requested in.
+func mergeRequestedItems(p []*pb.ItemsWithQuantity) []*pb.ItemsWithQuantity {
+	merged := make([]*pb.ItemsWithQuantity, 0, len(p))
+	byID := make(map[string]*pb.ItemsWithQuantity, len(p))
+
+	for _, item := range p {
+		if m, ok := byID[item.ID]; ok {
+			m.Quantity += item.Quantity
+			continue
+		}
+
+		m := &pb.ItemsWithQuantity{ID: item.ID, Quantity: item.Quantity}
+		byID[item.ID] = m
+		merged = append(merged, m)
+	}
+
+	return merged
+}
+
+func (s *Service) GetItems(ctx context.Context, ids []string) ([]*pb.Item, error) {
+	items, err := s.store.GetItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProto(items), nil
+}
+
+// ReserveItems holds the items of an order at a location for ttl, either all
+// of them or none.
+func (s *Service) ReserveItems(ctx context.Context, orderID, location string, p []*pb.ItemsWithQuantity, ttl time.Duration) (*Reservation, error) {
+	if len(p) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no items to reserve")
+	}
+
+	location, err := validateLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]int32)
+	ids := make([]string, 0, len(p))
+	items := make([]*ReservedItem, 0, len(p))
+	for _, item := range p {
+		if item.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity %d for item %s", item.Quantity, item.ID)
+		}
+		if _, ok := quantities[item.ID]; !ok {
+			ids = append(ids, item.ID)
+			items = append(items, &ReservedItem{ID: item.ID})
+		}
+		quantities[item.ID] += item.Quantity
+	}
+	for _, item := range items {
+		item.Quantity = quantities[item.ID]
+	}
+
+	if ttl <= 0 {
+		ttl = s.reservationTTL
+	}
+
+	now := time.Now()
+	r := &Reservation{
+		ID:         primitive.NewObjectID().Hex(),
+		OrderID:    orderID,
+		LocationID: location,
+		Status:     ReservationHeld,
+		Items:      items,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	}
+
+	err = s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		c, err := s.loadCatalog(ctx, location, ids)
+		if err != nil {
+			return err
+		}
+		for _, item := range r.Items {
+			stockItem, ok := c.items[item.ID]
+			if !ok {
+				continue
+			}
+			if stockItem.IngredientOnly {
+				return ErrItemNotForSale
+			}
+			if !stockItem.Schedule.OpenAt(now) {
+				return ErrItemUnavailable
+			}
+		}
+		r.Holds = c.stockLines(r.Items)
+
+		for _, item := range r.Holds {
+			e := reservationMovement(ctx, r, item.ID, MovementReservation, 0, item.Quantity, "")
+			if err := s.applyMovement(ctx, e); err != nil {
+				return err
 			}
 		}
+
+		return s.store.CreateReservation(ctx, r)
+	})
+	if err != nil {
+		return nil, s.unavailableError(ctx, r, err)
 	}
 
-	// create items with prices from stock
-	items := make([]*pb.Item, 0)
-	for _, stockItem := range itemsInStock {
-		for _, reqItem := range p {
-			if stockItem.ID == reqItem.ID {
-				items = append(items, &pb.Item{
-					ID:       stockItem.ID,
-					Name:     stockItem.Name,
-					PriceID:  stockItem.PriceID,
-					Quantity: reqItem.Quantity,
-				})
+	return r, nil
+}
+
+// CommitReservation takes the held items out of stock. Committing again is a
+// no-op. A reservation that already expired is only committed if its items
+// are still available.
+func (s *Service) CommitReservation(ctx context.Context, id string) (*Reservation, error) {
+	var (
+		r       *Reservation
+		entries []*LedgerEntry
+	)
+
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		entries = nil
+
+		var err error
+		r, err = s.getReservation(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		switch r.Status {
+		case ReservationCommitted:
+			return nil
+		case ReservationReleased:
+			return status.Errorf(codes.FailedPrecondition, "reservation %s was released", id)
+		}
+
+		for _, item := range r.Holds {
+			// the quantity of an expired reservation is no longer held
+			reserved := item.Quantity
+			if r.Status == ReservationExpired {
+				reserved = 0
+			}
+
+			e := reservationMovement(ctx, r, item.ID, MovementCommit, -item.Quantity, -reserved, "")
+			if err := s.applyMovement(ctx, e); err != nil {
+				return err
 			}
+			entries = append(entries, e)
 		}
+
+		r.Status = ReservationCommitted
+		return s.store.UpdateReservationStatus(ctx, id, r.Status)
+	})
+	if err != nil {
+		return nil, s.unavailableError(ctx, r, err)
 	}
 
-	return true, items, nil
+	s.notifyAlerts(ctx, entries)
+	return r, nil
 }
 
-func (s *Service) GetItems(ctx context.Context, ids []string) ([]*pb.Item, error) {
-	return s.store.GetItems(ctx, ids)
+// ReleaseReservation gives the held items back. Releasing a reservation that
+// is no longer held is a no-op, committed reservations can't be released.
+func (s *Service) ReleaseReservation(ctx context.Context, id string) (*Reservation, error) {
+	var r *Reservation
+
+	err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.getReservation(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		switch r.Status {
+		case ReservationReleased, ReservationExpired:
+			return nil
+		case ReservationCommitted:
+			return status.Errorf(codes.FailedPrecondition, "reservation %s was already committed", id)
+		}
+
+		return s.release(ctx, r, ReservationReleased, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Service) ExpireReservations(ctx context.Context, limit int64) (int, error) {
+	now := time.Now()
+
+	expired, err := s.store.ListExpiredReservations(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, candidate := range expired {
+		released := false
+
+		err := s.store.WithTransaction(ctx, func(ctx context.Context) error {
+			// it may have been committed or released since it was listed
+			r, err := s.store.GetReservation(ctx, candidate.ID)
+			if err != nil {
+				return err
+			}
+
+			released = r.Status == ReservationHeld && r.ExpiresAt.Before(now)
+			if !released {
+				return nil
+			}
+
+			return s.release(ctx, r, ReservationExpired, "reservation expired")
+		})
+		if err != nil {
+			return count, err
+		}
+
+		if released {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// notifyAlerts publishes the stock alerts the committed movements triggered.
+// Alerts are best effort and never fail the movement.
+func (s *Service) notifyAlerts(ctx context.Context, entries []*LedgerEntry) {
+	if s.alerts == nil || len(entries) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.ItemID)
+	}
+
+	items, err := s.store.GetItems(ctx, ids)
+	if err != nil {
+		log.Printf("failed to get items for stock alerts: %v", err)
+		return
+	}
+
+	s.alerts.Notify(ctx, items, entries)
+}
+
+func (s *Service) release(ctx context.Context, r *Reservation, to, reason string) error {
+	for _, item := range r.Holds {
+		e := reservationMovement(ctx, r, item.ID, MovementRelease, 0, -item.Quantity, reason)
+		if err := s.applyMovement(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	r.Status = to
+	return s.store.UpdateReservationStatus(ctx, r.ID, r.Status)
+}
+
+func (s *Service) getReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, err := s.store.GetReservation(ctx, id)
+	if errors.Is(err, ErrReservationNotFound) {
+		return nil, status.Errorf(codes.NotFound, "reservation %s not found", id)
+	}
+
+	return r, err
+}
+
+// unavailableError describes why the items of r could not be taken from
+// stock the same way CheckIfItemAreInStock does, for the orders service to
+// pass on. It runs after the transaction rolled back, so the items r managed
+// to take before failing don't count against it.
+func (s *Service) unavailableError(ctx context.Context, r *Reservation, err error) error {
+	if !errors.Is(err, ErrItemNotFound) && !errors.Is(err, ErrInsufficientStock) && !errors.Is(err, ErrItemUnavailable) && !errors.Is(err, ErrItemNotForSale) {
+		return err
+	}
+
+	requested := make([]*pb.ItemsWithQuantity, 0, len(r.Items))
+	for _, item := range r.Items {
+		requested = append(requested, &pb.ItemsWithQuantity{ID: item.ID, Quantity: item.Quantity})
+	}
+
+	_, _, availability, checkErr := s.CheckIfItemAreInStock(ctx, r.LocationID, requested)
+	if checkErr != nil {
+		return checkErr
+	}
+
+	unavailable := make([]*pb.ItemAvailability, 0, len(availability))
+	for _, a := range availability {
+		if a.Status != common.AvailabilityOK {
+			unavailable = append(unavailable, a)
+		}
+	}
+	if len(unavailable) == 0 {
+		// the stock changed since the attempt
+		return status.Error(codes.Aborted, "stock changed while taking the items, try again")
+	}
+
+	return common.NewStockError(unavailable)
+}
+
+// stockError turns the store errors about an item into gRPC errors.
+func stockError(itemID string, err error) error {
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		return status.Errorf(codes.NotFound, "item %s not found", itemID)
+	case errors.Is(err, ErrInsufficientStock):
+		return status.Errorf(codes.FailedPrecondition, "not enough stock for item %s", itemID)
+	case errors.Is(err, ErrLedgerDrift):
+		return status.Error(codes.DataLoss, err.Error())
+	default:
+		return err
+	}
+}
+
+func toProto(items []*Item) []*pb.Item {
+	res := make([]*pb.Item, 0, len(items))
+	for _, item := range items {
+		res = append(res, item.ToProto())
+	}
+
+	return res
 }
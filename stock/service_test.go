@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	common "github.com/rikughi/commons"
+	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestService serves the given items from a memory store, their levels
+// seeded as the initial restock.
+func newTestService(t *testing.T, items ...*Item) *Service {
+	t.Helper()
+
+	svc := NewService(NewMemoryStore(), time.Minute, nil)
+	if err := svc.Seed(context.Background(), items); err != nil {
+		t.Fatalf("seeding the stock: %v", err)
+	}
+
+	return svc
+}
+
+func stockedItem(id string, quantity int32) *Item {
+	return &Item{
+		ID:         id,
+		Name:       id,
+		UnitAmount: 100,
+		Currency:   "usd",
+		Levels:     map[string]*StockLevel{common.DefaultLocation: {Quantity: quantity}},
+	}
+}
+
+func ledgerTypes(t *testing.T, svc *Service, itemID string) []string {
+	t.Helper()
+
+	entries, err := svc.store.ListLedger(context.Background(), itemID, common.DefaultLocation, primitive.NilObjectID, 100)
+	if err != nil {
+		t.Fatalf("listing the ledger: %v", err)
+	}
+
+	types := make([]string, 0, len(entries))
+	for _, e := range entries {
+		types = append(types, e.Type)
+	}
+
+	return types
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		// settle ends the reservation the way the test is about
+		settle       func(ctx context.Context, svc *Service, id string) error
+		wantStatus   string
+		wantQuantity int32
+		wantLedger   []string
+	}{
+		{
+			name: "commit",
+			ttl:  time.Minute,
+			settle: func(ctx context.Context, svc *Service, id string) error {
+				_, err := svc.CommitReservation(ctx, id)
+				return err
+			},
+			wantStatus:   ReservationCommitted,
+			wantQuantity: 7,
+			wantLedger:   []string{MovementRestock, MovementReservation, MovementCommit},
+		},
+		{
+			name: "release",
+			ttl:  time.Minute,
+			settle: func(ctx context.Context, svc *Service, id string) error {
+				_, err := svc.ReleaseReservation(ctx, id)
+				return err
+			},
+			wantStatus:   ReservationReleased,
+			wantQuantity: 10,
+			wantLedger:   []string{MovementRestock, MovementReservation, MovementRelease},
+		},
+		{
+			name: "expiry",
+			ttl:  time.Millisecond,
+			settle: func(ctx context.Context, svc *Service, id string) error {
+				time.Sleep(5 * time.Millisecond)
+				n, err := svc.ExpireReservations(ctx, 10)
+				if err == nil && n != 1 {
+					t.Errorf("ExpireReservations released %d reservations, want 1", n)
+				}
+				return err
+			},
+			wantStatus:   ReservationExpired,
+			wantQuantity: 10,
+			wantLedger:   []string{MovementRestock, MovementReservation, MovementRelease},
+		},
+		{
+			name: "commit after expiry",
+			ttl:  time.Millisecond,
+			settle: func(ctx context.Context, svc *Service, id string) error {
+				time.Sleep(5 * time.Millisecond)
+				if _, err := svc.ExpireReservations(ctx, 10); err != nil {
+					return err
+				}
+				_, err := svc.CommitReservation(ctx, id)
+				return err
+			},
+			wantStatus:   ReservationCommitted,
+			wantQuantity: 7,
+			wantLedger:   []string{MovementRestock, MovementReservation, MovementRelease, MovementCommit},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := newTestService(t, stockedItem("chips", 10))
+
+			r, err := svc.ReserveItems(ctx, "order", "", []*pb.ItemsWithQuantity{{ID: "chips", Quantity: 3}}, tt.ttl)
+			if err != nil {
+				t.Fatalf("ReserveItems: %v", err)
+			}
+
+			item, _ := svc.store.GetItem(ctx, "chips")
+			if got := item.Level(common.DefaultLocation).Reserved; got != 3 {
+				t.Fatalf("reserved %d, want 3", got)
+			}
+
+			if err := tt.settle(ctx, svc, r.ID); err != nil {
+				t.Fatalf("settling the reservation: %v", err)
+			}
+
+			r, err = svc.store.GetReservation(ctx, r.ID)
+			if err != nil {
+				t.Fatalf("GetReservation: %v", err)
+			}
+			if r.Status != tt.wantStatus {
+				t.Errorf("status %q, want %q", r.Status, tt.wantStatus)
+			}
+
+			item, _ = svc.store.GetItem(ctx, "chips")
+			level := item.Level(common.DefaultLocation)
+			if level.Quantity != tt.wantQuantity || level.Reserved != 0 {
+				t.Errorf("quantity %d reserved %d, want %d and 0", level.Quantity, level.Reserved, tt.wantQuantity)
+			}
+
+			quantity, reserved, _ := svc.store.SumLedger(ctx, "chips", common.DefaultLocation)
+			if quantity != level.Quantity || reserved != level.Reserved {
+				t.Errorf("ledger sums to %d/%d, the level is %d/%d", quantity, reserved, level.Quantity, level.Reserved)
+			}
+
+			if got := ledgerTypes(t, svc, "chips"); !slices.Equal(got, tt.wantLedger) {
+				t.Errorf("ledger %v, want %v", got, tt.wantLedger)
+			}
+		})
+	}
+}
+
+func TestReserveItemsRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []*pb.ItemsWithQuantity
+		wantCode codes.Code
+		// wantViolation is the availability status reported for the item
+		wantViolation string
+	}{
+		{
+			name:          "more than available",
+			items:         []*pb.ItemsWithQuantity{{ID: "chips", Quantity: 11}},
+			wantCode:      codes.FailedPrecondition,
+			wantViolation: common.AvailabilityInsufficient,
+		},
+		{
+			name:          "unknown item",
+			items:         []*pb.ItemsWithQuantity{{ID: "soda", Quantity: 1}},
+			wantCode:      codes.FailedPrecondition,
+			wantViolation: common.AvailabilityUnknown,
+		},
+		{
+			name:          "ingredient",
+			items:         []*pb.ItemsWithQuantity{{ID: "bun", Quantity: 1}},
+			wantCode:      codes.FailedPrecondition,
+			wantViolation: common.AvailabilityNotForSale,
+		},
+		{
+			name:     "no quantity",
+			items:    []*pb.ItemsWithQuantity{{ID: "chips", Quantity: 0}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			bun := stockedItem("bun", 10)
+			bun.IngredientOnly = true
+			svc := newTestService(t, stockedItem("chips", 10), bun)
+
+			_, err := svc.ReserveItems(ctx, "order", "", tt.items, time.Minute)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("error %v, want code %v", err, tt.wantCode)
+			}
+			if tt.wantViolation != "" {
+				if got := violationType(err); got != tt.wantViolation {
+					t.Errorf("violation %q, want %q", got, tt.wantViolation)
+				}
+			}
+
+			// nothing is left held
+			for _, id := range []string{"chips", "bun"} {
+				item, _ := svc.store.GetItem(ctx, id)
+				if reserved := item.Level(common.DefaultLocation).Reserved; reserved != 0 {
+					t.Errorf("%s has %d reserved, want 0", id, reserved)
+				}
+			}
+		})
+	}
+}
+
+func violationType(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok && len(failure.Violations) > 0 {
+			return failure.Violations[0].Type
+		}
+	}
+
+	return ""
+}
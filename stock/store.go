@@ -2,51 +2,550 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type Store struct {
-	stock map[string]*pb.Item
+const (
+	DbName               = "stock"
+	ItemsCollName        = "items"
+	ReservationsCollName = "reservations"
+	LedgerCollName       = "ledger"
+	// PurchaseOrdersCollName holds the orders placed with suppliers
+	PurchaseOrdersCollName = "purchaseOrders"
+)
+
+type store struct {
+	db *mongo.Client
+}
+
+func NewStore(db *mongo.Client) *store {
+	return &store{db}
 }
 
-func NewStore() *Store {
-	return &Store{
-		stock: map[string]*pb.Item{
-			"2": {
-				ID:       "2",
-				Name:     "Potato Chips",
-				PriceID:  "price_1PXCP6CurvtCrm27wdWxYtC9",
-				Quantity: 10,
-			},
-			"1": {
-				ID:       "1",
-				Name:     "Cheese Burger",
-				PriceID:  "price_1PXCLBCurvtCrm27fJwKdjU4",
-				Quantity: 20,
-			},
-		},
+func (s *store) GetItem(ctx context.Context, id string) (*Item, error) {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	var i Item
+	err := col.FindOne(ctx, bson.M{"_id": id}).Decode(&i)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
+
+	return &i, nil
 }
 
-func (s *Store) GetItem(ctx context.Context, id string) (*pb.Item, error) {
-	for _, item := range s.stock {
-		if item.ID == id {
-			return item, nil
+func (s *store) GetItems(ctx context.Context, ids []string) ([]*Item, error) {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	cursor, err := col.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s *store) CreateItem(ctx context.Context, item *Item) error {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	_, err := col.InsertOne(ctx, item)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrItemExists
+	}
+
+	return err
+}
+
+func (s *store) UpdateItem(ctx context.Context, item *Item) error {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	res, err := col.UpdateOne(ctx,
+		bson.M{"_id": item.ID},
+		bson.M{"$set": bson.M{
+			"name":       item.Name,
+			"priceID":    item.PriceID,
+			"unitAmount": item.UnitAmount,
+			"currency":   item.Currency,
+			"recipe":     item.Recipe,
+			"schedule":   item.Schedule,
+
+			"lowStockThreshold": item.LowStockThreshold,
+			"reorderPoint":      item.ReorderPoint,
+			"ingredientOnly":    item.IngredientOnly,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (s *store) DeleteItem(ctx context.Context, id string) error {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	res, err := col.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (s *store) ListItems(ctx context.Context, f ListItemsFilter) ([]*Item, error) {
+	col := s.db.Database(DbName).Collection(ItemsCollName)
+
+	filter := bson.M{}
+	if f.After != "" {
+		filter["_id"] = bson.M{"$gt": f.After}
+	}
+	if f.ExcludeDepleted {
+		// items made from ingredients hold no stock, the service checks those
+		filter["$or"] = bson.A{
+			bson.M{levelField(f.Location, "quantity"): bson.M{"$gt": 0}},
+			bson.M{"recipe.0": bson.M{"$exists": true}},
 		}
 	}
+	if f.Ingredient != "" {
+		filter["recipe.itemID"] = f.Ingredient
+	}
+	if f.SellableOnly {
+		filter["ingredientOnly"] = bson.M{"$ne": true}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(f.Limit)
+
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
 
-	return nil, fmt.Errorf("item not found")
+	return items, nil
 }
 
-func (s *Store) GetItems(ctx context.Context, ids []string) ([]*pb.Item, error) {
-	var res []*pb.Item
-	for _, id := range ids {
-		if i, ok := s.stock[id]; ok {
-			res = append(res, i)
+// levelField is the path of a field of the stock level at a location.
+// Location IDs are validated by the service to be usable in paths.
+func levelField(location, field string) string {
+	return "levels." + location + "." + field
+}
+
+// ApplyMovement updates the item and appends the entry in one transaction,
+// so the ledger and the item never disagree.
+func (s *store) ApplyMovement(ctx context.Context, e *LedgerEntry) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		col := s.db.Database(DbName).Collection(ItemsCollName)
+
+		quantityField := levelField(e.LocationID, "quantity")
+		reservedField := levelField(e.LocationID, "reserved")
+		lotsField := levelField(e.LocationID, "lots")
+
+		// the level doesn't exist until the location gets stock of the item
+		quantity := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + quantityField, 0}}, e.QuantityDelta}}
+		reserved := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + reservedField, 0}}, e.ReservedDelta}}
+
+		conditions := bson.A{
+			bson.M{"$gte": bson.A{reserved, 0}},
+			bson.M{"$gte": bson.A{quantity, reserved}},
+		}
+		if e.ReservedDelta > 0 {
+			// expired lots can't be reserved
+			conditions = append(conditions, bson.M{"$gte": bson.A{bson.M{"$subtract": bson.A{quantity, expiredQuantity(lotsField, e.At)}}, reserved}})
+		}
+
+		// the filter only matches when the item can take the change, so
+		// concurrent updates can never oversell it
+		filter := bson.M{"_id": e.ItemID, "$expr": bson.M{"$and": conditions}}
+		inc := bson.M{quantityField: e.QuantityDelta, reservedField: e.ReservedDelta}
+		update := bson.M{"$inc": inc}
+
+		var (
+			lotConditions bson.A
+			arrayFilters  []interface{}
+			emptied       bool
+		)
+		for i, m := range e.Lots {
+			if m.NewLot {
+				update["$push"] = bson.M{lotsField: Lot{ID: m.LotID, Quantity: m.QuantityDelta, ReceivedAt: e.At, ExpiresAt: m.ExpiresAt}}
+				continue
+			}
+
+			name := fmt.Sprintf("lot%d", i)
+			inc[lotsField+".$["+name+"].quantity"] = m.QuantityDelta
+			arrayFilters = append(arrayFilters, bson.M{name + ".id": m.LotID})
+			lotConditions = append(lotConditions, bson.M{lotsField: bson.M{"$elemMatch": bson.M{
+				"id":       m.LotID,
+				"quantity": bson.M{"$gte": -m.QuantityDelta},
+			}}})
+			emptied = emptied || m.QuantityDelta < 0
+		}
+		if len(lotConditions) > 0 {
+			filter["$and"] = lotConditions
+		}
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		if len(arrayFilters) > 0 {
+			opts.SetArrayFilters(options.ArrayFilters{Filters: arrayFilters})
+		}
+
+		var item Item
+		err := col.FindOneAndUpdate(ctx, filter, update, opts).Decode(&item)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if _, err := s.GetItem(ctx, e.ItemID); err != nil {
+				return err
+			}
+			return ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		if emptied {
+			_, err := col.UpdateOne(ctx,
+				bson.M{"_id": e.ItemID},
+				bson.M{"$pull": bson.M{lotsField: bson.M{"quantity": bson.M{"$lte": 0}}}},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		level := item.Level(e.LocationID)
+		e.Quantity = level.Quantity
+		e.Reserved = level.Reserved
+
+		// the transaction reads the ledger as it was before e
+		ledgerQuantity, ledgerReserved, err := s.SumLedger(ctx, e.ItemID, e.LocationID)
+		if err != nil {
+			return err
 		}
+		if err := checkBalances(e, ledgerQuantity, ledgerReserved); err != nil {
+			return err
+		}
+
+		_, err = s.db.Database(DbName).Collection(LedgerCollName).InsertOne(ctx, e)
+		return err
+	})
+}
+
+// expiredQuantity is an expression adding up the quantities of the lots in
+// lotsField expired at t.
+func expiredQuantity(lotsField string, t time.Time) bson.M {
+	// lots that don't expire have no expiresAt, which compares below any date
+	expired := bson.M{"$and": bson.A{
+		bson.M{"$gt": bson.A{"$$this.expiresAt", nil}},
+		bson.M{"$lte": bson.A{"$$this.expiresAt", t}},
+	}}
+
+	return bson.M{"$reduce": bson.M{
+		"input":        bson.M{"$ifNull": bson.A{"$" + lotsField, bson.A{}}},
+		"initialValue": 0,
+		"in":           bson.M{"$add": bson.A{"$$value", bson.M{"$cond": bson.A{expired, "$$this.quantity", 0}}}},
+	}}
+}
+
+func (s *store) ListLedger(ctx context.Context, itemID, location string, after primitive.ObjectID, limit int64) ([]*LedgerEntry, error) {
+	col := s.db.Database(DbName).Collection(LedgerCollName)
+
+	filter := bson.M{"itemID": itemID, "locationID": location}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*LedgerEntry, 0)
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *store) SumLedger(ctx context.Context, itemID, location string) (int32, int32, error) {
+	col := s.db.Database(DbName).Collection(LedgerCollName)
+
+	cursor, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"itemID": itemID, "locationID": location}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      nil,
+			"quantity": bson.M{"$sum": "$quantityDelta"},
+			"reserved": bson.M{"$sum": "$reservedDelta"},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var sums []struct {
+		Quantity int32 `bson:"quantity"`
+		Reserved int32 `bson:"reserved"`
 	}
+	if err := cursor.All(ctx, &sums); err != nil {
+		return 0, 0, err
+	}
+
+	if len(sums) == 0 {
+		return 0, 0, nil
+	}
+
+	return sums[0].Quantity, sums[0].Reserved, nil
+}
+
+// SumConsumption adds up what was committed at a location since a time, by
+// item ID.
+func (s *store) SumConsumption(ctx context.Context, location string, since time.Time) (map[string]int32, error) {
+	col := s.db.Database(DbName).Collection(LedgerCollName)
+
+	cursor, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"type": MovementCommit, "locationID": location, "at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$itemID",
+			"consumed": bson.M{"$sum": bson.M{"$multiply": bson.A{"$quantityDelta", -1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sums []struct {
+		ItemID   string `bson:"_id"`
+		Consumed int32  `bson:"consumed"`
+	}
+	if err := cursor.All(ctx, &sums); err != nil {
+		return nil, err
+	}
+
+	consumed := make(map[string]int32, len(sums))
+	for _, sum := range sums {
+		consumed[sum.ItemID] = sum.Consumed
+	}
+
+	return consumed, nil
+}
+
+func (s *store) CreateReservation(ctx context.Context, r *Reservation) error {
+	col := s.db.Database(DbName).Collection(ReservationsCollName)
+
+	_, err := col.InsertOne(ctx, r)
+	return err
+}
+
+func (s *store) GetReservation(ctx context.Context, id string) (*Reservation, error) {
+	col := s.db.Database(DbName).Collection(ReservationsCollName)
+
+	var r Reservation
+	err := col.FindOne(ctx, bson.M{"_id": id}).Decode(&r)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (s *store) UpdateReservationStatus(ctx context.Context, id, status string) error {
+	col := s.db.Database(DbName).Collection(ReservationsCollName)
+
+	res, err := col.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"status": status}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func (s *store) ListExpiredReservations(ctx context.Context, now time.Time, limit int64) ([]*Reservation, error) {
+	col := s.db.Database(DbName).Collection(ReservationsCollName)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "expiresAt", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := col.Find(ctx, bson.M{
+		"status":    ReservationHeld,
+		"expiresAt": bson.M{"$lt": now},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*Reservation, 0)
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (s *store) CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error {
+	col := s.db.Database(DbName).Collection(PurchaseOrdersCollName)
+
+	_, err := col.InsertOne(ctx, po)
+	return err
+}
+
+func (s *store) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error) {
+	col := s.db.Database(DbName).Collection(PurchaseOrdersCollName)
+
+	var po PurchaseOrder
+	err := col.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &po, nil
+}
+
+func (s *store) UpdatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error {
+	col := s.db.Database(DbName).Collection(PurchaseOrdersCollName)
+
+	res, err := col.UpdateOne(ctx,
+		bson.M{"_id": po.ID},
+		bson.M{"$set": bson.M{
+			"status":    po.Status,
+			"lines":     po.Lines,
+			"updatedAt": po.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrPurchaseOrderNotFound
+	}
+
+	return nil
+}
+
+func (s *store) ListPurchaseOrders(ctx context.Context, f PurchaseOrderFilter) ([]*PurchaseOrder, error) {
+	col := s.db.Database(DbName).Collection(PurchaseOrdersCollName)
+
+	filter := bson.M{}
+	if len(f.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": f.Statuses}
+	}
+	if f.Location != "" {
+		filter["locationID"] = f.Location
+	}
+	if f.After != "" {
+		filter["_id"] = bson.M{"$gt": f.After}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(f.Limit)
+
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*PurchaseOrder, 0)
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (s *store) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		// already within a transaction
+		return fn(ctx)
+	}
+
+	session, err := s.db.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	return err
+}
+
+// EnsureIndexes creates the indexes the reservation sweeper, the ledger
+// queries and the replenishment queries rely on.
+func (s *store) EnsureIndexes(ctx context.Context) error {
+	col := s.db.Database(DbName).Collection(ReservationsCollName)
+
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "expiresAt", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	ledger := s.db.Database(DbName).Collection(LedgerCollName)
+
+	_, err = ledger.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "itemID", Value: 1}, {Key: "locationID", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = ledger.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "type", Value: 1}, {Key: "locationID", Value: 1}, {Key: "at", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	orders := s.db.Database(DbName).Collection(PurchaseOrdersCollName)
+
+	_, err = orders.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "locationID", Value: 1}, {Key: "_id", Value: 1}},
+	})
 
-	return res, nil
+	return err
 }
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const sweepBatchSize = 100
+
+// reservationSweeper releases the reservations nobody committed or released
+// before their TTL ran out, e.g. because the orders service went away.
+type reservationSweeper struct {
+	service  StockService
+	interval time.Duration
+}
+
+func NewReservationSweeper(service StockService, interval time.Duration) *reservationSweeper {
+	return &reservationSweeper{service, interval}
+}
+
+func (s *reservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := s.service.ExpireReservations(ctx, sweepBatchSize)
+			if err != nil {
+				log.Printf("failed to expire reservations: %v", err)
+			}
+			if released > 0 {
+				log.Printf("released %d expired reservations", released)
+			}
+		}
+	}
+}
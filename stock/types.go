@@ -2,16 +2,297 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sort"
+	"time"
 
+	common "github.com/rikughi/commons"
 	pb "github.com/rikughi/commons/api"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrItemNotFound        = errors.New("item not found")
+	ErrItemExists          = errors.New("item already exists")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrItemUnavailable     = errors.New("item can't be ordered now")
+	ErrItemNotForSale      = errors.New("item is not sold on its own")
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrLedgerDrift is returned when the quantities of an item no longer
+	// add up to its ledger
+	ErrLedgerDrift           = errors.New("the stock level doesn't match its ledger")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
 )
 
 type StockService interface {
-	CheckIfItemAreInStock(context.Context, []*pb.ItemsWithQuantity) (bool, []*pb.Item, error)
+	CheckIfItemAreInStock(ctx context.Context, location string, items []*pb.ItemsWithQuantity) (bool, []*pb.Item, []*pb.ItemAvailability, error)
 	GetItems(ctx context.Context, ids []string) ([]*pb.Item, error)
+	ReserveItems(ctx context.Context, orderID, location string, items []*pb.ItemsWithQuantity, ttl time.Duration) (*Reservation, error)
+	CommitReservation(ctx context.Context, id string) (*Reservation, error)
+	ReleaseReservation(ctx context.Context, id string) (*Reservation, error)
+	// ExpireReservations releases the holds that outlived their TTL and
+	// returns how many it released.
+	ExpireReservations(ctx context.Context, limit int64) (int, error)
+
+	CreateItem(ctx context.Context, item *Item) (*Item, error)
+	UpdateItem(ctx context.Context, item *Item) (*Item, error)
+	DeleteItem(ctx context.Context, id string) (*Item, error)
+	ListItems(ctx context.Context, p *pb.ListItemsRequest) ([]*Item, string, error)
+	AdjustQuantity(ctx context.Context, p *pb.AdjustQuantityRequest) (*Item, error)
+	GetItemLedger(ctx context.Context, itemID, location string, pageSize int, pageToken string) (*ItemLedger, error)
+	ImportItems(ctx context.Context, format, location string, dryRun bool, r io.Reader) (*ImportResult, error)
+	ExportItems(ctx context.Context, format, location string, w io.Writer) error
+	// ExpiringLots lists the lots with stock left that expire by until.
+	ExpiringLots(ctx context.Context, until time.Time) ([]*ExpiringLot, error)
+
+	ReorderSuggestions(ctx context.Context, p *pb.ReorderSuggestionsRequest) (*ReorderSuggestions, error)
+	CreatePurchaseOrder(ctx context.Context, p *pb.CreatePurchaseOrderRequest) (*PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, p *pb.ListPurchaseOrdersRequest) ([]*PurchaseOrder, string, error)
+	ReceivePurchaseOrder(ctx context.Context, p *pb.ReceivePurchaseOrderRequest) (*PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error)
 }
 
 type StockStore interface {
-	GetItem(ctx context.Context, id string) (*pb.Item, error)
-	GetItems(ctx context.Context, ids []string) ([]*pb.Item, error)
+	GetItem(ctx context.Context, id string) (*Item, error)
+	// GetItems skips the IDs it does not know.
+	GetItems(ctx context.Context, ids []string) ([]*Item, error)
+	CreateItem(ctx context.Context, item *Item) error
+	// UpdateItem replaces the catalog details of an item, leaving its
+	// quantities alone.
+	UpdateItem(ctx context.Context, item *Item) error
+	DeleteItem(ctx context.Context, id string) error
+	ListItems(ctx context.Context, f ListItemsFilter) ([]*Item, error)
+	// ApplyMovement moves the on hand and reserved quantities of an item at
+	// the location of e by the deltas of e and records e in the item's
+	// ledger, filling in the balances. It fails with ErrInsufficientStock
+	// instead of holding more than is on hand, and with ErrLedgerDrift when
+	// the quantities it moves don't add up to the ledger.
+	ApplyMovement(ctx context.Context, e *LedgerEntry) error
+	// ListLedger returns up to limit entries of an item at a location,
+	// oldest first, starting after the given entry ID.
+	ListLedger(ctx context.Context, itemID, location string, after primitive.ObjectID, limit int64) ([]*LedgerEntry, error)
+	// SumLedger adds up all the entries of an item at a location.
+	SumLedger(ctx context.Context, itemID, location string) (quantity, reserved int32, err error)
+	CreateReservation(ctx context.Context, r *Reservation) error
+	GetReservation(ctx context.Context, id string) (*Reservation, error)
+	UpdateReservationStatus(ctx context.Context, id, status string) error
+	// ListExpiredReservations returns held reservations that expired before now.
+	ListExpiredReservations(ctx context.Context, now time.Time, limit int64) ([]*Reservation, error)
+	// SumConsumption adds up what was committed to orders at a location since
+	// the given time, by item ID.
+	SumConsumption(ctx context.Context, location string, since time.Time) (map[string]int32, error)
+	CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error
+	GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error)
+	// UpdatePurchaseOrder replaces the status and lines of a purchase order.
+	UpdatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error
+	ListPurchaseOrders(ctx context.Context, f PurchaseOrderFilter) ([]*PurchaseOrder, error)
+	// WithTransaction runs fn atomically: the store calls fn makes with the
+	// context it is given are rolled back if fn returns an error.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ListItemsFilter narrows down the catalog. Results are ordered by ID and
+// After, when set, is the ID of the last item of the previous page.
+type ListItemsFilter struct {
+	After string
+	Limit int64
+	// ExcludeDepleted leaves out the items with nothing on hand at Location
+	ExcludeDepleted bool
+	Location        string
+	// Ingredient, when set, only keeps the items whose recipe uses it
+	Ingredient string
+	// SellableOnly leaves out the items only used as ingredients
+	SellableOnly bool
+}
+
+type Item struct {
+	ID         string `bson:"_id" json:"id"`
+	Name       string `bson:"name" json:"name"`
+	PriceID    string `bson:"priceID" json:"priceID"`
+	UnitAmount int64  `bson:"unitAmount" json:"unitAmount"`
+	Currency   string `bson:"currency" json:"currency"`
+	// Levels is the stock of the item by location, locations that never
+	// held any are left out
+	Levels map[string]*StockLevel `bson:"levels,omitempty" json:"levels,omitempty"`
+	// stock.low is published when the quantity on hand at a location drops
+	// to it, 0 disables it
+	LowStockThreshold int32 `bson:"lowStockThreshold" json:"lowStockThreshold"`
+	// Recipe lists the ingredients the item is made from, if any
+	Recipe []Ingredient `bson:"recipe,omitempty" json:"recipe,omitempty"`
+	// Schedule restricts when the item can be ordered, any time when nil
+	Schedule *Schedule `bson:"schedule,omitempty" json:"schedule,omitempty"`
+	// ReorderPoint is the stock position at which replenishment suggests
+	// ordering more, 0 leaves it to consumption alone
+	ReorderPoint int32 `bson:"reorderPoint,omitempty" json:"reorderPoint,omitempty"`
+	// IngredientOnly items are only used in recipes, they are neither on the
+	// menu nor can they be ordered on their own
+	IngredientOnly bool `bson:"ingredientOnly,omitempty" json:"ingredientOnly,omitempty"`
+}
+
+// StockLevel is the stock of an item at a location. Quantity is on hand,
+// Reserved of it is held for pending orders. Lots tell when the stock on
+// hand was received and when it expires.
+type StockLevel struct {
+	Quantity int32  `bson:"quantity" json:"quantity"`
+	Reserved int32  `bson:"reserved" json:"reserved"`
+	Lots     []*Lot `bson:"lots,omitempty" json:"lots,omitempty"`
+}
+
+// Available is the quantity that can still be reserved, expired lots can't.
+func (l StockLevel) Available() int32 {
+	return l.Quantity - l.Reserved - l.Expired(time.Now())
+}
+
+// Level is the stock of the item at a location.
+func (i *Item) Level(location string) StockLevel {
+	if l, ok := i.Levels[location]; ok {
+		return *l
+	}
+
+	return StockLevel{}
+}
+
+// locations lists the locations holding stock of the item, sorted.
+func (i *Item) locations() []string {
+	return sortedLocations(i.Levels)
+}
+
+func sortedLocations(levels map[string]*StockLevel) []string {
+	locations := make([]string, 0, len(levels))
+	for location := range levels {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	return locations
+}
+
+func (i *Item) ToProto() *pb.Item {
+	return &pb.Item{
+		ID:         i.ID,
+		Name:       i.Name,
+		PriceID:    i.PriceID,
+		UnitAmount: i.UnitAmount,
+		Currency:   i.Currency,
+	}
+}
+
+// ToStockItem describes the item with its quantities at a location.
+func (i *Item) ToStockItem(location string) *pb.StockItem {
+	recipe := make([]*pb.Ingredient, 0, len(i.Recipe))
+	for _, ingredient := range i.Recipe {
+		recipe = append(recipe, ingredient.ToProto())
+	}
+
+	now := time.Now()
+	levels := make([]*pb.StockLevel, 0, len(i.Levels))
+	for _, id := range i.locations() {
+		l := i.Level(id)
+		lots := make([]*pb.Lot, 0, len(l.Lots))
+		for _, lot := range l.Lots {
+			lots = append(lots, lot.ToProto(now))
+		}
+
+		levels = append(levels, &pb.StockLevel{
+			LocationID: id,
+			Quantity:   l.Quantity,
+			Reserved:   l.Reserved,
+			Available:  l.Available(),
+			Expired:    l.Expired(now),
+			Lots:       lots,
+		})
+	}
+
+	l := i.Level(location)
+	return &pb.StockItem{
+		ID:         i.ID,
+		Name:       i.Name,
+		PriceID:    i.PriceID,
+		UnitAmount: i.UnitAmount,
+		Currency:   i.Currency,
+		Quantity:   l.Quantity,
+		Reserved:   l.Reserved,
+		Available:  l.Available(),
+		Recipe:     recipe,
+		LocationID: location,
+		Levels:     levels,
+		Schedule:   i.Schedule.ToProto(),
+
+		LowStockThreshold: i.LowStockThreshold,
+		ReorderPoint:      i.ReorderPoint,
+		IngredientOnly:    i.IngredientOnly,
+	}
+}
+
+// itemFromProto reads an item to create or update. Its quantity, if any, is
+// the initial stock at its location.
+func itemFromProto(p *pb.StockItem) *Item {
+	item := &Item{
+		ID:         p.ID,
+		Name:       p.Name,
+		PriceID:    p.PriceID,
+		UnitAmount: p.UnitAmount,
+		Currency:   p.Currency,
+		Recipe:     recipeFromProto(p.Recipe),
+		Schedule:   scheduleFromProto(p.Schedule),
+
+		LowStockThreshold: p.LowStockThreshold,
+		ReorderPoint:      p.ReorderPoint,
+		IngredientOnly:    p.IngredientOnly,
+	}
+	if p.Quantity != 0 {
+		item.Levels = map[string]*StockLevel{
+			common.LocationOrDefault(p.LocationID): {Quantity: p.Quantity},
+		}
+	}
+
+	return item
+}
+
+const (
+	ReservationHeld      = "held"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+type ReservedItem struct {
+	ID       string `bson:"id"`
+	Quantity int32  `bson:"quantity"`
+}
+
+// Reservation holds items for an order until it is committed, released or
+// its TTL runs out.
+type Reservation struct {
+	ID         string          `bson:"_id"`
+	OrderID    string          `bson:"orderID,omitempty"`
+	LocationID string          `bson:"locationID"`
+	Status     string          `bson:"status"`
+	Items      []*ReservedItem `bson:"items"`
+	// Holds is the stock Items draw from, with recipes broken down into
+	// their ingredients when the reservation was made
+	Holds     []*ReservedItem `bson:"holds,omitempty"`
+	CreatedAt time.Time       `bson:"createdAt"`
+	ExpiresAt time.Time       `bson:"expiresAt"`
+}
+
+func (r *Reservation) ToProto() *pb.Reservation {
+	items := make([]*pb.ItemsWithQuantity, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, &pb.ItemsWithQuantity{
+			ID:       item.ID,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return &pb.Reservation{
+		ID:         r.ID,
+		Status:     r.Status,
+		Items:      items,
+		ExpiresAt:  r.ExpiresAt.Unix(),
+		OrderID:    r.OrderID,
+		LocationID: r.LocationID,
+	}
 }